@@ -0,0 +1,173 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package merkletree
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// serializationVersion is the version of the binary encoding of the
+// multiproofs, written as their first byte.
+const serializationVersion uint8 = 1
+
+// maxPrealloc bounds the number of items allocated from a length prefix
+// before they are read, so that a malformed input can't trigger a huge
+// allocation; longer slices grow as their items are read.
+const maxPrealloc = 1 << 12
+
+var errUnsupportedVersion = errors.New("unsupported serialization version")
+
+// WriteTo writes the binary encoding of the multiproof to w. The first byte
+// is the version of the encoding, the indices and the number of leaves are
+// big endian uint64, the slices are prefixed by their length as a big endian
+// uint32.
+func (proof *MultiProof) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	if err := writeVersion(w, &n); err != nil {
+		return n, err
+	}
+	if err := writeUint32(w, &n, uint32(len(proof.Indices))); err != nil {
+		return n, err
+	}
+	for _, index := range proof.Indices {
+		if err := writeUint64(w, &n, index); err != nil {
+			return n, err
+		}
+	}
+	if err := writeByteSlices(w, &n, proof.Leaves); err != nil {
+		return n, err
+	}
+	if err := writeByteSlices(w, &n, proof.Hashes); err != nil {
+		return n, err
+	}
+	err := writeUint64(w, &n, proof.NumLeaves)
+	return n, err
+}
+
+// ReadFrom reads a multiproof written by WriteTo from r.
+func (proof *MultiProof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	if err := readVersion(r, &n); err != nil {
+		return n, err
+	}
+	nbIndices, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	proof.Indices = make([]uint64, 0, min(nbIndices, maxPrealloc))
+	for i := uint32(0); i < nbIndices; i++ {
+		index, err := readUint64(r, &n)
+		if err != nil {
+			return n, err
+		}
+		proof.Indices = append(proof.Indices, index)
+	}
+	if proof.Leaves, err = readByteSlices(r, &n); err != nil {
+		return n, err
+	}
+	if proof.Hashes, err = readByteSlices(r, &n); err != nil {
+		return n, err
+	}
+	proof.NumLeaves, err = readUint64(r, &n)
+	return n, err
+}
+
+func writeVersion(w io.Writer, n *int64) error {
+	m, err := w.Write([]byte{serializationVersion})
+	*n += int64(m)
+	return err
+}
+
+func writeUint32(w io.Writer, n *int64, v uint32) error {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	m, err := w.Write(buf[:])
+	*n += int64(m)
+	return err
+}
+
+func writeUint64(w io.Writer, n *int64, v uint64) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	m, err := w.Write(buf[:])
+	*n += int64(m)
+	return err
+}
+
+func writeByteSlices(w io.Writer, n *int64, s [][]byte) error {
+	if err := writeUint32(w, n, uint32(len(s))); err != nil {
+		return err
+	}
+	for i := range s {
+		if err := writeUint32(w, n, uint32(len(s[i]))); err != nil {
+			return err
+		}
+		m, err := w.Write(s[i])
+		*n += int64(m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readVersion(r io.Reader, n *int64) error {
+	var buf [1]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	if err != nil {
+		return err
+	}
+	if buf[0] != serializationVersion {
+		return errUnsupportedVersion
+	}
+	return nil
+}
+
+func readUint32(r io.Reader, n *int64) (uint32, error) {
+	var buf [4]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	return binary.BigEndian.Uint32(buf[:]), err
+}
+
+func readUint64(r io.Reader, n *int64) (uint64, error) {
+	var buf [8]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	return binary.BigEndian.Uint64(buf[:]), err
+}
+
+func readByteSlices(r io.Reader, n *int64) ([][]byte, error) {
+	l, err := readUint32(r, n)
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]byte, 0, min(l, maxPrealloc))
+	for i := uint32(0); i < l; i++ {
+		b, err := readBytes(r, n)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+// readBytes reads a length prefixed byte slice. The slice grows as the bytes
+// are read, so that its length prefix alone can't trigger a huge allocation.
+func readBytes(r io.Reader, n *int64) ([]byte, error) {
+	l, err := readUint32(r, n)
+	if err != nil {
+		return nil, err
+	}
+	res, err := io.ReadAll(io.LimitReader(r, int64(l)))
+	*n += int64(len(res))
+	if err == nil && len(res) != int(l) {
+		err = io.ErrUnexpectedEOF
+	}
+	return res, err
+}
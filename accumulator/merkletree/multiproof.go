@@ -0,0 +1,209 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"math/bits"
+	"slices"
+)
+
+var (
+	ErrNoLeaves        = errors.New("the tree must have at least one leaf")
+	ErrNoIndices       = errors.New("a multiproof needs at least one index")
+	ErrIndexOutOfRange = errors.New("leaf index out of range")
+)
+
+// FullTree is a Merkle tree whose nodes are all stored, so that it can prove any set of
+// leaves. It has the same shape and the same root as a Tree built by pushing the same leaves.
+type FullTree struct {
+	h hash.Hash
+
+	leaves [][]byte
+
+	// levels[j][i] is the root of the complete subtree of leaves [i⋅2ʲ, (i+1)⋅2ʲ)
+	levels [][][]byte
+
+	// spine[lo] is the root of the incomplete subtree of leaves [lo, numLeaves)
+	spine map[uint64][]byte
+}
+
+// NewFullTree builds the Merkle tree of leaves. The leaves are hashed with h, and are not copied.
+func NewFullTree(h hash.Hash, leaves [][]byte) (*FullTree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+	t := &FullTree{
+		h:      h,
+		leaves: leaves,
+		spine:  make(map[uint64][]byte),
+	}
+
+	level := make([][]byte, len(leaves))
+	for i := range leaves {
+		level[i] = leafSum(h, leaves[i])
+	}
+	t.levels = append(t.levels, level)
+	for len(level) > 1 {
+		next := make([][]byte, len(level)/2)
+		for i := range next {
+			next[i] = nodeSum(h, level[2*i], level[2*i+1])
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+
+	t.spineHash(0, t.NumLeaves())
+	return t, nil
+}
+
+// NumLeaves returns the number of leaves of the tree.
+func (t *FullTree) NumLeaves() uint64 {
+	return uint64(len(t.leaves))
+}
+
+// Root returns the Merkle root of the tree.
+func (t *FullTree) Root() []byte {
+	return t.hash(0, t.NumLeaves())
+}
+
+// hash returns the root of the subtree of leaves [lo, hi).
+func (t *FullTree) hash(lo, hi uint64) []byte {
+	size := hi - lo
+	if size&(size-1) == 0 {
+		j := bits.TrailingZeros64(size)
+		return t.levels[j][lo>>j]
+	}
+	return t.spine[lo]
+}
+
+// spineHash computes and stores the roots of the incomplete subtrees of leaves [lo, hi).
+func (t *FullTree) spineHash(lo, hi uint64) []byte {
+	size := hi - lo
+	if size&(size-1) == 0 {
+		return t.hash(lo, hi)
+	}
+	k := splitPoint(size)
+	res := nodeSum(t.h, t.hash(lo, lo+k), t.spineHash(lo+k, hi))
+	t.spine[lo] = res
+	return res
+}
+
+// MultiProof proves that several leaves are in a Merkle tree. The siblings shared by the
+// paths of several leaves, and the nodes that can be computed from the leaves, are included
+// only once.
+type MultiProof struct {
+	// Indices of the proven leaves, in increasing order.
+	Indices []uint64
+
+	// Leaves data of the proven leaves (not hashed).
+	Leaves [][]byte
+
+	// Hashes of the subtrees without any proven leaf, needed to compute the root,
+	// in the order of a depth-first, left-to-right traversal of the tree.
+	Hashes [][]byte
+
+	// NumLeaves number of leaves of the tree.
+	NumLeaves uint64
+}
+
+// ProveMulti returns a multiproof of the leaves at indices. Duplicate indices are removed.
+func (t *FullTree) ProveMulti(indices []uint64) (MultiProof, error) {
+	if len(indices) == 0 {
+		return MultiProof{}, ErrNoIndices
+	}
+	sorted := slices.Clone(indices)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	if sorted[len(sorted)-1] >= t.NumLeaves() {
+		return MultiProof{}, ErrIndexOutOfRange
+	}
+
+	proof := MultiProof{
+		Indices:   sorted,
+		Leaves:    make([][]byte, len(sorted)),
+		NumLeaves: t.NumLeaves(),
+	}
+	for i, idx := range sorted {
+		proof.Leaves[i] = t.leaves[idx]
+	}
+
+	var walk func(indices []uint64, lo, hi uint64)
+	walk = func(indices []uint64, lo, hi uint64) {
+		if len(indices) == 0 {
+			proof.Hashes = append(proof.Hashes, t.hash(lo, hi))
+			return
+		}
+		if hi-lo == 1 {
+			return
+		}
+		mid := lo + splitPoint(hi-lo)
+		left, right := splitIndices(indices, mid)
+		walk(left, lo, mid)
+		walk(right, mid, hi)
+	}
+	walk(sorted, 0, t.NumLeaves())
+
+	return proof, nil
+}
+
+// VerifyMultiProof returns true if the leaves of the proof are at their indices in the Merkle
+// tree of root merkleRoot.
+func VerifyMultiProof(h hash.Hash, merkleRoot []byte, proof MultiProof) bool {
+	if merkleRoot == nil || len(proof.Indices) == 0 || len(proof.Indices) != len(proof.Leaves) {
+		return false
+	}
+	for i := range proof.Indices {
+		if i > 0 && proof.Indices[i] <= proof.Indices[i-1] {
+			return false
+		}
+	}
+	if proof.Indices[len(proof.Indices)-1] >= proof.NumLeaves {
+		return false
+	}
+
+	hashes := proof.Hashes
+	leaves := proof.Leaves
+	var compute func(indices []uint64, lo, hi uint64) []byte
+	compute = func(indices []uint64, lo, hi uint64) []byte {
+		if len(indices) == 0 {
+			if len(hashes) == 0 {
+				return nil
+			}
+			res := hashes[0]
+			hashes = hashes[1:]
+			return res
+		}
+		if hi-lo == 1 {
+			res := leafSum(h, leaves[0])
+			leaves = leaves[1:]
+			return res
+		}
+		mid := lo + splitPoint(hi-lo)
+		left, right := splitIndices(indices, mid)
+		l := compute(left, lo, mid)
+		r := compute(right, mid, hi)
+		if l == nil || r == nil {
+			return nil
+		}
+		return nodeSum(h, l, r)
+	}
+	root := compute(proof.Indices, 0, proof.NumLeaves)
+
+	return root != nil && len(hashes) == 0 && bytes.Equal(root, merkleRoot)
+}
+
+// splitPoint returns the number of leaves of the left subtree of a tree of n > 1 leaves:
+// the largest power of 2 smaller than n.
+func splitPoint(n uint64) uint64 {
+	return 1 << (bits.Len64(n-1) - 1)
+}
+
+// splitIndices splits sorted indices into the ones smaller than mid and the others.
+func splitIndices(indices []uint64, mid uint64) (left, right []uint64) {
+	i, _ := slices.BinarySearch(indices, mid)
+	return indices[:i], indices[i:]
+}
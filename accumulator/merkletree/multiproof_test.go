@@ -0,0 +1,169 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = binary.BigEndian.AppendUint64(nil, uint64(i)*0x9e3779b97f4a7c15)
+	}
+	return leaves
+}
+
+func TestFullTreeRoot(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+
+	for n := 1; n <= 33; n++ {
+		leaves := testLeaves(n)
+		tree := New(h)
+		for _, l := range leaves {
+			tree.Push(l)
+		}
+		full, err := NewFullTree(h, leaves)
+		assert.NoError(err)
+		assert.Equal(tree.Root(), full.Root(), "n=%d", n)
+	}
+
+	_, err := NewFullTree(h, nil)
+	assert.ErrorIs(err, ErrNoLeaves)
+}
+
+func TestMultiProof(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+
+	for _, n := range []int{1, 2, 7, 8, 13, 32} {
+		leaves := testLeaves(n)
+		tree, err := NewFullTree(h, leaves)
+		assert.NoError(err)
+		root := tree.Root()
+
+		// every single leaf, and every pair of leaves
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				proof, err := tree.ProveMulti([]uint64{uint64(j), uint64(i)})
+				assert.NoError(err)
+				assert.True(VerifyMultiProof(h, root, proof), "n=%d i=%d j=%d", n, i, j)
+			}
+		}
+
+		// all the leaves: no hash is needed
+		all := make([]uint64, n)
+		for i := range all {
+			all[i] = uint64(i)
+		}
+		proof, err := tree.ProveMulti(all)
+		assert.NoError(err)
+		assert.Empty(proof.Hashes)
+		assert.True(VerifyMultiProof(h, root, proof))
+	}
+
+	tree, err := NewFullTree(h, testLeaves(13))
+	assert.NoError(err)
+	root := tree.Root()
+
+	_, err = tree.ProveMulti(nil)
+	assert.ErrorIs(err, ErrNoIndices)
+	_, err = tree.ProveMulti([]uint64{2, 13})
+	assert.ErrorIs(err, ErrIndexOutOfRange)
+
+	// the shared part of the paths is included once
+	proof, err := tree.ProveMulti([]uint64{4, 5})
+	assert.NoError(err)
+	_, single, _, _ := proveSingle(h, testLeaves(13), 4)
+	assert.Len(proof.Hashes, len(single)-2)
+
+	// wrong leaf
+	proof, err = tree.ProveMulti([]uint64{3, 9})
+	assert.NoError(err)
+	proof.Leaves[1] = []byte("wrong")
+	assert.False(VerifyMultiProof(h, root, proof))
+
+	// wrong index
+	proof, err = tree.ProveMulti([]uint64{3, 9})
+	assert.NoError(err)
+	proof.Indices[1] = 10
+	assert.False(VerifyMultiProof(h, root, proof))
+
+	// missing or extra hash
+	proof, err = tree.ProveMulti([]uint64{3, 9})
+	assert.NoError(err)
+	valid := proof.Hashes
+	proof.Hashes = valid[1:]
+	assert.False(VerifyMultiProof(h, root, proof))
+	proof.Hashes = append(valid, valid[0])
+	assert.False(VerifyMultiProof(h, root, proof))
+
+	// unsorted indices
+	proof, err = tree.ProveMulti([]uint64{3, 9})
+	assert.NoError(err)
+	proof.Indices[0], proof.Indices[1] = proof.Indices[1], proof.Indices[0]
+	proof.Leaves[0], proof.Leaves[1] = proof.Leaves[1], proof.Leaves[0]
+	assert.False(VerifyMultiProof(h, root, proof))
+}
+
+func TestMultiProofSerialization(t *testing.T) {
+	assert := require.New(t)
+	h := sha256.New()
+
+	tree, err := NewFullTree(h, testLeaves(13))
+	assert.NoError(err)
+	proof, err := tree.ProveMulti([]uint64{1, 2, 11})
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+
+	var decoded MultiProof
+	read, err := decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.Equal(proof, decoded)
+	assert.True(VerifyMultiProof(h, tree.Root(), decoded))
+
+	// truncated encoding
+	buf.Reset()
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+	encoded := buf.Bytes()
+	_, err = decoded.ReadFrom(bytes.NewReader(encoded[:len(encoded)-9]))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// length prefixes larger than the input aren't allocated upfront
+	_, err = decoded.ReadFrom(bytes.NewReader([]byte{serializationVersion, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 1}))
+	assert.ErrorIs(err, io.EOF)
+	_, err = decoded.ReadFrom(bytes.NewReader([]byte{serializationVersion, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 1, 2}))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// unknown version
+	encoded[0]++
+	_, err = decoded.ReadFrom(bytes.NewReader(encoded))
+	assert.ErrorIs(err, errUnsupportedVersion)
+}
+
+// proveSingle returns the single-leaf proof of the Tree of leaves at index.
+func proveSingle(h hash.Hash, leaves [][]byte, index uint64) ([]byte, [][]byte, uint64, uint64) {
+	tree := New(h)
+	if err := tree.SetIndex(index); err != nil {
+		panic(err)
+	}
+	for _, l := range leaves {
+		tree.Push(l)
+	}
+	return tree.Prove()
+}
@@ -0,0 +1,523 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"slices"
+
+	gcHash "github.com/consensys/gnark-crypto/hash"
+)
+
+// SparseDepth is the depth of a SparseTree: leaves are indexed by 256-bit keys.
+const SparseDepth = 256
+
+// SparseKeySize is the size in bytes of the keys of a SparseTree.
+const SparseKeySize = SparseDepth / 8
+
+var (
+	ErrInvalidKeySize = errors.New("sparse merkle tree keys must be 32 bytes long")
+	ErrKeyNotFound    = errors.New("key not found in the sparse merkle tree")
+	ErrKeyExists      = errors.New("key already in the sparse merkle tree")
+	ErrEmptyValue     = errors.New("the value of a leaf can't be empty")
+	ErrInvalidProof   = errors.New("invalid sparse merkle tree proof")
+	ErrInvalidNbKeys  = errors.New("a multiproof needs as many values as keys, and at least one key")
+	ErrDuplicateKey   = errors.New("duplicate key in multiproof")
+)
+
+// SparseHasher hashes the nodes of a SparseTree.
+//
+// A leaf is the hash of its key and its value, an empty leaf is zero, and an inner node the hash
+// of its two children. The hashes of the empty subtrees are precomputed.
+type SparseHasher struct {
+	leaf func(key, value []byte) ([]byte, error)
+	node func(left, right []byte) ([]byte, error)
+
+	// empty[d] is the hash of an empty subtree rooted at depth d
+	empty [SparseDepth + 1][]byte
+}
+
+// NewSparseHasher returns a SparseHasher using h: a leaf is H(key ∥ value), a node H(left ∥ right).
+func NewSparseHasher(h hash.Hash) *SparseHasher {
+	hashFn := func(a, b []byte) ([]byte, error) {
+		return sum(h, a, b), nil
+	}
+	return newSparseHasher(hashFn, hashFn, h.Size())
+}
+
+// NewSparseCompressorHasher returns a SparseHasher using the compression function c, for example
+// Poseidon2, so that the proofs can be verified efficiently in a circuit: a leaf is
+// c(key, value) and a node c(left, right).
+//
+// Keys and values must then be valid inputs of c, that is c.BlockSize() bytes long and, for
+// compression functions over a prime field, canonical field elements.
+func NewSparseCompressorHasher(c gcHash.Compressor) *SparseHasher {
+	return newSparseHasher(c.Compress, c.Compress, c.BlockSize())
+}
+
+func newSparseHasher(leaf, node func([]byte, []byte) ([]byte, error), size int) *SparseHasher {
+	sh := &SparseHasher{leaf: leaf, node: node}
+	sh.empty[SparseDepth] = make([]byte, size)
+	for d := SparseDepth - 1; d >= 0; d-- {
+		var err error
+		if sh.empty[d], err = node(sh.empty[d+1], sh.empty[d+1]); err != nil {
+			panic(err)
+		}
+	}
+	return sh
+}
+
+// EmptyRoot returns the root of an empty SparseTree.
+func (sh *SparseHasher) EmptyRoot() []byte {
+	return sh.empty[0]
+}
+
+func (sh *SparseHasher) leafHash(key, value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return sh.empty[SparseDepth], nil
+	}
+	return sh.leaf(key, value)
+}
+
+// parent returns the hash of the node at depth d-1 with the given child at depth d.
+func (sh *SparseHasher) parent(key []byte, d int, child, sibling []byte) ([]byte, error) {
+	if bit(key, d-1) == 0 {
+		return sh.node(child, sibling)
+	}
+	return sh.node(sibling, child)
+}
+
+// SparseStorage stores the nodes and the values of a SparseTree.
+// Get returns a nil value if key is not in the storage.
+type SparseStorage interface {
+	Get(key []byte) ([]byte, error)
+	Set(key, value []byte) error
+	Delete(key []byte) error
+}
+
+// MemoryStorage is an in-memory SparseStorage.
+type MemoryStorage struct {
+	m map[string][]byte
+}
+
+// NewMemoryStorage returns an empty in-memory SparseStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{m: make(map[string][]byte)}
+}
+
+// Get implements SparseStorage.
+func (s *MemoryStorage) Get(key []byte) ([]byte, error) {
+	return s.m[string(key)], nil
+}
+
+// Set implements SparseStorage.
+func (s *MemoryStorage) Set(key, value []byte) error {
+	s.m[string(key)] = bytes.Clone(value)
+	return nil
+}
+
+// Delete implements SparseStorage.
+func (s *MemoryStorage) Delete(key []byte) error {
+	delete(s.m, string(key))
+	return nil
+}
+
+// Len returns the number of entries in the storage.
+func (s *MemoryStorage) Len() int {
+	return len(s.m)
+}
+
+// SparseTree is a sparse Merkle tree of depth 256, whose leaves are indexed by 32-byte keys.
+//
+// Only the non-empty nodes are stored: the node at depth d on the path of a key is stored
+// under (d, the first d bits of the key), and the values under their key. The tree reads its
+// state from the storage, so that a tree can be reopened from a persistent storage.
+type SparseTree struct {
+	hasher  *SparseHasher
+	storage SparseStorage
+}
+
+// NewSparseTree returns the SparseTree stored in storage, empty if storage is empty.
+func NewSparseTree(hasher *SparseHasher, storage SparseStorage) *SparseTree {
+	return &SparseTree{hasher: hasher, storage: storage}
+}
+
+// Root returns the root of the tree.
+func (t *SparseTree) Root() ([]byte, error) {
+	return t.nodeHash(make([]byte, SparseKeySize), 0)
+}
+
+// Get returns the value of key, or ErrKeyNotFound.
+func (t *SparseTree) Get(key []byte) ([]byte, error) {
+	if len(key) != SparseKeySize {
+		return nil, ErrInvalidKeySize
+	}
+	value, err := t.storage.Get(valueStorageKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, ErrKeyNotFound
+	}
+	return value, nil
+}
+
+// Has returns true if key is in the tree.
+func (t *SparseTree) Has(key []byte) (bool, error) {
+	_, err := t.Get(key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Insert inserts a new leaf in the tree. It returns ErrKeyExists if key is already in the tree.
+func (t *SparseTree) Insert(key, value []byte) error {
+	if len(value) == 0 {
+		return ErrEmptyValue
+	}
+	if has, err := t.Has(key); err != nil || has {
+		if has {
+			return ErrKeyExists
+		}
+		return err
+	}
+	return t.set(key, value)
+}
+
+// Update updates the value of a leaf. It returns ErrKeyNotFound if key is not in the tree.
+func (t *SparseTree) Update(key, value []byte) error {
+	if len(value) == 0 {
+		return ErrEmptyValue
+	}
+	if has, err := t.Has(key); err != nil || !has {
+		if err == nil {
+			return ErrKeyNotFound
+		}
+		return err
+	}
+	return t.set(key, value)
+}
+
+// Delete removes a leaf from the tree. It returns ErrKeyNotFound if key is not in the tree.
+func (t *SparseTree) Delete(key []byte) error {
+	if has, err := t.Has(key); err != nil || !has {
+		if err == nil {
+			return ErrKeyNotFound
+		}
+		return err
+	}
+	return t.set(key, nil)
+}
+
+// set sets the value of key, and updates the nodes on its path. A nil value deletes the leaf.
+func (t *SparseTree) set(key, value []byte) error {
+	h, err := t.hasher.leafHash(key, value)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		err = t.storage.Delete(valueStorageKey(key))
+	} else {
+		err = t.storage.Set(valueStorageKey(key), value)
+	}
+	if err != nil {
+		return err
+	}
+
+	for d := SparseDepth; d >= 0; d-- {
+		if err := t.setNodeHash(key, d, h); err != nil {
+			return err
+		}
+		if d == 0 {
+			break
+		}
+		sibling, err := t.nodeHash(siblingKey(key, d), d)
+		if err != nil {
+			return err
+		}
+		if h, err = t.hasher.parent(key, d, h, sibling); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeHash returns the hash of the node at depth d on the path of key.
+func (t *SparseTree) nodeHash(key []byte, d int) ([]byte, error) {
+	h, err := t.storage.Get(nodeStorageKey(key, d))
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return t.hasher.empty[d], nil
+	}
+	return h, nil
+}
+
+// setNodeHash stores the hash of the node at depth d on the path of key. Empty nodes are not stored.
+func (t *SparseTree) setNodeHash(key []byte, d int, h []byte) error {
+	if bytes.Equal(h, t.hasher.empty[d]) {
+		return t.storage.Delete(nodeStorageKey(key, d))
+	}
+	return t.storage.Set(nodeStorageKey(key, d), h)
+}
+
+// SparseProof proves that a key is in a SparseTree with a given value (membership), or that it
+// is not in the tree (non-membership).
+type SparseProof struct {
+	Key []byte
+	// Value is the value of Key, or nil for a non-membership proof.
+	Value []byte
+	// Siblings[d] is the hash of the sibling of the node at depth d+1 on the path of Key, from
+	// the children of the root to the leaves.
+	Siblings [][]byte
+}
+
+// Prove returns a membership proof of key if it is in the tree, or a non-membership proof
+// otherwise.
+func (t *SparseTree) Prove(key []byte) (*SparseProof, error) {
+	value, err := t.Get(key)
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		return nil, err
+	}
+	proof := &SparseProof{
+		Key:      bytes.Clone(key),
+		Value:    value,
+		Siblings: make([][]byte, SparseDepth),
+	}
+	for d := 1; d <= SparseDepth; d++ {
+		if proof.Siblings[d-1], err = t.nodeHash(siblingKey(key, d), d); err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
+
+// VerifyProof verifies a membership or non-membership proof against root.
+func (sh *SparseHasher) VerifyProof(root []byte, proof *SparseProof) error {
+	if len(proof.Key) != SparseKeySize {
+		return ErrInvalidKeySize
+	}
+	if len(proof.Siblings) != SparseDepth {
+		return ErrInvalidProof
+	}
+	if proof.Value != nil && len(proof.Value) == 0 {
+		return ErrEmptyValue
+	}
+	h, err := sh.leafHash(proof.Key, proof.Value)
+	if err != nil {
+		return err
+	}
+	for d := SparseDepth; d > 0; d-- {
+		if h, err = sh.parent(proof.Key, d, h, proof.Siblings[d-1]); err != nil {
+			return err
+		}
+	}
+	if !bytes.Equal(h, root) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// SparseMultiProof proves the values of several keys at once (nil for the keys that are not
+// in the tree). The siblings that can be computed from the proven leaves, and the empty
+// subtrees, are not included.
+type SparseMultiProof struct {
+	Keys   [][]byte
+	Values [][]byte
+	// Siblings are the hashes of the non-empty subtrees needed to recompute the root, in the
+	// order of a depth-first, left-to-right traversal of the tree.
+	Siblings [][]byte
+	// EmptySiblings[i] is true if the i-th sibling needed, in the same order, is an empty subtree.
+	EmptySiblings []bool
+}
+
+// ProveBatch returns a multiproof of the values of keys.
+func (t *SparseTree) ProveBatch(keys [][]byte) (*SparseMultiProof, error) {
+	if len(keys) == 0 {
+		return nil, ErrInvalidNbKeys
+	}
+	sorted, err := sortKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	proof := &SparseMultiProof{
+		Keys:   sorted,
+		Values: make([][]byte, len(sorted)),
+	}
+	for i := range sorted {
+		if proof.Values[i], err = t.Get(sorted[i]); err != nil && !errors.Is(err, ErrKeyNotFound) {
+			return nil, err
+		}
+	}
+
+	// collect the siblings, in the order in which VerifyMultiProof consumes them
+	var walk func(keys [][]byte, d int) error
+	walk = func(keys [][]byte, d int) error {
+		if d == SparseDepth {
+			return nil
+		}
+		left, right := splitKeys(keys, d)
+		for _, side := range [][][]byte{left, right} {
+			if len(side) != 0 {
+				if err := walk(side, d+1); err != nil {
+					return err
+				}
+				continue
+			}
+			// the sibling subtree of keys[0] at depth d+1
+			h, err := t.nodeHash(siblingKey(keys[0], d+1), d+1)
+			if err != nil {
+				return err
+			}
+			isEmpty := bytes.Equal(h, t.hasher.empty[d+1])
+			proof.EmptySiblings = append(proof.EmptySiblings, isEmpty)
+			if !isEmpty {
+				proof.Siblings = append(proof.Siblings, h)
+			}
+		}
+		return nil
+	}
+	if err := walk(sorted, 0); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyMultiProof verifies a multiproof against root.
+func (sh *SparseHasher) VerifyMultiProof(root []byte, proof *SparseMultiProof) error {
+	if len(proof.Keys) == 0 || len(proof.Keys) != len(proof.Values) {
+		return ErrInvalidNbKeys
+	}
+	sorted, err := sortKeys(proof.Keys)
+	if err != nil {
+		return err
+	}
+	for i := range sorted {
+		if !bytes.Equal(sorted[i], proof.Keys[i]) {
+			return ErrInvalidProof
+		}
+	}
+	values := make(map[string][]byte, len(proof.Keys))
+	for i := range proof.Keys {
+		if proof.Values[i] != nil && len(proof.Values[i]) == 0 {
+			return ErrEmptyValue
+		}
+		values[string(proof.Keys[i])] = proof.Values[i]
+	}
+
+	siblings, empties := proof.Siblings, proof.EmptySiblings
+	nextSibling := func(d int) ([]byte, error) {
+		if len(empties) == 0 {
+			return nil, ErrInvalidProof
+		}
+		isEmpty := empties[0]
+		empties = empties[1:]
+		if isEmpty {
+			return sh.empty[d], nil
+		}
+		if len(siblings) == 0 {
+			return nil, ErrInvalidProof
+		}
+		h := siblings[0]
+		siblings = siblings[1:]
+		return h, nil
+	}
+
+	var compute func(keys [][]byte, d int) ([]byte, error)
+	compute = func(keys [][]byte, d int) ([]byte, error) {
+		if d == SparseDepth {
+			return sh.leafHash(keys[0], values[string(keys[0])])
+		}
+		left, right := splitKeys(keys, d)
+		var children [2][]byte
+		for i, side := range [][][]byte{left, right} {
+			var err error
+			if len(side) != 0 {
+				children[i], err = compute(side, d+1)
+			} else {
+				children[i], err = nextSibling(d + 1)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		return sh.node(children[0], children[1])
+	}
+
+	h, err := compute(sorted, 0)
+	if err != nil {
+		return err
+	}
+	if len(siblings) != 0 || len(empties) != 0 || !bytes.Equal(h, root) {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// sortKeys returns a sorted copy of keys, checking their size and that they are distinct.
+func sortKeys(keys [][]byte) ([][]byte, error) {
+	sorted := make([][]byte, len(keys))
+	for i := range keys {
+		if len(keys[i]) != SparseKeySize {
+			return nil, ErrInvalidKeySize
+		}
+		sorted[i] = bytes.Clone(keys[i])
+	}
+	slices.SortFunc(sorted, bytes.Compare)
+	for i := 1; i < len(sorted); i++ {
+		if bytes.Equal(sorted[i-1], sorted[i]) {
+			return nil, ErrDuplicateKey
+		}
+	}
+	return sorted, nil
+}
+
+// splitKeys splits sorted keys sharing their first d bits according to their bit d.
+func splitKeys(keys [][]byte, d int) (left, right [][]byte) {
+	i, _ := slices.BinarySearchFunc(keys, 1, func(key []byte, _ int) int {
+		return int(bit(key, d)) - 1
+	})
+	return keys[:i], keys[i:]
+}
+
+// bit returns the bit i of key, starting from the most significant bit.
+func bit(key []byte, i int) byte {
+	return (key[i/8] >> (7 - i%8)) & 1
+}
+
+// siblingKey returns key with its bit d-1 flipped: the sibling of the node at depth d on the
+// path of key is on the path of siblingKey(key, d).
+func siblingKey(key []byte, d int) []byte {
+	res := bytes.Clone(key)
+	res[(d-1)/8] ^= 1 << (7 - (d-1)%8)
+	return res
+}
+
+// nodeStorageKey returns the storage key of the node at depth d on the path of key:
+// 'n' ∥ d ∥ the first d bits of key.
+func nodeStorageKey(key []byte, d int) []byte {
+	res := make([]byte, 3+SparseKeySize)
+	res[0] = 'n'
+	res[1], res[2] = byte(d>>8), byte(d)
+	copy(res[3:], key)
+	// clear the bits after the first d
+	for i := d; i < SparseDepth; i++ {
+		if i%8 == 0 {
+			clear(res[3+i/8:])
+			break
+		}
+		res[3+i/8] &^= 1 << (7 - i%8)
+	}
+	return res
+}
+
+// valueStorageKey returns the storage key of the value of key: 'v' ∥ key.
+func valueStorageKey(key []byte) []byte {
+	return append([]byte{'v'}, key...)
+}
@@ -0,0 +1,195 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package merkletree
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
+)
+
+// randomKeys returns n random keys, smaller than the bn254 scalar field modulus.
+func randomKeys(t *testing.T, n int) [][]byte {
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = make([]byte, SparseKeySize)
+		_, err := rand.Read(keys[i])
+		require.NoError(t, err)
+		keys[i][0] &= 0x0f
+	}
+	return keys
+}
+
+func sparseHashers() map[string]*SparseHasher {
+	return map[string]*SparseHasher{
+		"sha256":    NewSparseHasher(sha256.New()),
+		"poseidon2": NewSparseCompressorHasher(poseidon2.NewPermutation(2, 6, 50)),
+	}
+}
+
+func TestSparseTree(t *testing.T) {
+	for name, hasher := range sparseHashers() {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			storage := NewMemoryStorage()
+			tree := NewSparseTree(hasher, storage)
+			root, err := tree.Root()
+			assert.NoError(err)
+			assert.Equal(hasher.EmptyRoot(), root)
+
+			keys := randomKeys(t, 8)
+			values := randomKeys(t, len(keys))
+			for i := range keys {
+				assert.NoError(tree.Insert(keys[i], values[i]))
+			}
+			assert.ErrorIs(tree.Insert(keys[0], values[1]), ErrKeyExists)
+			for i := range keys {
+				v, err := tree.Get(keys[i])
+				assert.NoError(err)
+				assert.Equal(values[i], v)
+			}
+
+			// the root doesn't depend on the order of insertion
+			root, err = tree.Root()
+			assert.NoError(err)
+			other := NewSparseTree(hasher, NewMemoryStorage())
+			for i := len(keys) - 1; i >= 0; i-- {
+				assert.NoError(other.Insert(keys[i], values[i]))
+			}
+			otherRoot, err := other.Root()
+			assert.NoError(err)
+			assert.Equal(root, otherRoot)
+
+			// the tree can be reopened from its storage
+			reopened, err := NewSparseTree(hasher, storage).Root()
+			assert.NoError(err)
+			assert.Equal(root, reopened)
+
+			// update
+			absent := randomKeys(t, 1)[0]
+			assert.ErrorIs(tree.Update(absent, values[0]), ErrKeyNotFound)
+			assert.NoError(tree.Update(keys[0], values[1]))
+			updated, err := tree.Root()
+			assert.NoError(err)
+			assert.NotEqual(root, updated)
+			assert.NoError(tree.Update(keys[0], values[0]))
+			updated, err = tree.Root()
+			assert.NoError(err)
+			assert.Equal(root, updated)
+
+			// delete
+			assert.ErrorIs(tree.Delete(absent), ErrKeyNotFound)
+			for i := range keys {
+				assert.NoError(tree.Delete(keys[i]))
+			}
+			_, err = tree.Get(keys[0])
+			assert.ErrorIs(err, ErrKeyNotFound)
+			root, err = tree.Root()
+			assert.NoError(err)
+			assert.Equal(hasher.EmptyRoot(), root)
+			assert.Equal(0, storage.Len())
+
+			assert.ErrorIs(tree.Insert(keys[0][1:], values[0]), ErrInvalidKeySize)
+			assert.ErrorIs(tree.Insert(keys[0], []byte{}), ErrEmptyValue)
+		})
+	}
+}
+
+func TestSparseTreeProof(t *testing.T) {
+	for name, hasher := range sparseHashers() {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			tree := NewSparseTree(hasher, NewMemoryStorage())
+			keys := randomKeys(t, 16)
+			values := randomKeys(t, len(keys))
+			for i := 0; i < len(keys)/2; i++ {
+				assert.NoError(tree.Insert(keys[i], values[i]))
+			}
+			root, err := tree.Root()
+			assert.NoError(err)
+
+			for i := range keys {
+				proof, err := tree.Prove(keys[i])
+				assert.NoError(err)
+				if i < len(keys)/2 {
+					assert.Equal(values[i], proof.Value, "membership proof")
+				} else {
+					assert.Nil(proof.Value, "non-membership proof")
+				}
+				assert.NoError(hasher.VerifyProof(root, proof))
+
+				// wrong value
+				proof.Value = values[(i+1)%len(values)]
+				assert.ErrorIs(hasher.VerifyProof(root, proof), ErrInvalidProof)
+			}
+
+			// non-membership of a key sharing a long prefix with a member
+			key := make([]byte, SparseKeySize)
+			copy(key, keys[0])
+			key[SparseKeySize-1] ^= 1
+			proof, err := tree.Prove(key)
+			assert.NoError(err)
+			assert.Nil(proof.Value)
+			assert.NoError(hasher.VerifyProof(root, proof))
+			proof.Value = values[0]
+			assert.ErrorIs(hasher.VerifyProof(root, proof), ErrInvalidProof)
+		})
+	}
+}
+
+func TestSparseTreeMultiProof(t *testing.T) {
+	for name, hasher := range sparseHashers() {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			tree := NewSparseTree(hasher, NewMemoryStorage())
+			keys := randomKeys(t, 32)
+			values := randomKeys(t, len(keys))
+			for i := 0; i < 24; i++ {
+				assert.NoError(tree.Insert(keys[i], values[i]))
+			}
+			root, err := tree.Root()
+			assert.NoError(err)
+
+			// members and non members
+			proven := append([][]byte{}, keys[16:]...)
+			proof, err := tree.ProveBatch(proven)
+			assert.NoError(err)
+			assert.Len(proof.Keys, len(proven))
+			assert.NoError(hasher.VerifyMultiProof(root, proof))
+
+			// smaller than the single proofs
+			assert.Less(len(proof.EmptySiblings), SparseDepth*len(proven))
+
+			// a single key
+			single, err := tree.ProveBatch(keys[:1])
+			assert.NoError(err)
+			assert.NoError(hasher.VerifyMultiProof(root, single))
+
+			// wrong value
+			for i := range proof.Values {
+				if proof.Values[i] == nil {
+					proof.Values[i] = values[0]
+					break
+				}
+			}
+			assert.ErrorIs(hasher.VerifyMultiProof(root, proof), ErrInvalidProof)
+
+			// truncated proof
+			single.Siblings = single.Siblings[1:]
+			assert.ErrorIs(hasher.VerifyMultiProof(root, single), ErrInvalidProof)
+
+			_, err = tree.ProveBatch([][]byte{keys[0], keys[0]})
+			assert.ErrorIs(err, ErrDuplicateKey)
+			_, err = tree.ProveBatch(nil)
+			assert.ErrorIs(err, ErrInvalidNbKeys)
+		})
+	}
+}
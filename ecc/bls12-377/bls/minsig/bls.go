@@ -0,0 +1,358 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minsig
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/signature"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePublicKey  = bls12377.SizeOfG2AffineCompressed
+	sizePrivateKey = sizeFr + sizePublicKey
+	sizeSignature  = bls12377.SizeOfG1AffineCompressed
+
+	// keyGenL number of bytes of HKDF output used to derive a secret key, ceil((3 * ceil(log2(r))) / 16)
+	keyGenL = (3*fr.Bits + 15) / 16
+)
+
+var (
+	ErrInvalidPublicKey  = errors.New("invalid public key (point at infinity or not in the subgroup)")
+	ErrShortIKM          = errors.New("input keying material must be at least 32 bytes")
+	ErrEmptyAggregation  = errors.New("nothing to aggregate")
+	ErrInvalidNbMessages = errors.New("number of public keys and messages differ")
+	ErrNotSupported      = errors.New("operation not supported by this ciphersuite")
+	ErrDuplicateMessages = errors.New("messages must be distinct in the Basic ciphersuite")
+)
+
+// PublicKey represents a BLS public key, a point of G2
+type PublicKey struct {
+	A bls12377.G2Affine
+}
+
+// PrivateKey represents a BLS private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a BLS signature, a point of G1
+type Signature struct {
+	S bls12377.G1Affine
+}
+
+// Ciphersuite is one of the BLS signature schemes of draft-irtf-cfrg-bls-signature.
+// It fixes the domain separation tag used to hash messages to G1 and
+// how rogue key attacks are prevented when aggregating signatures.
+type Ciphersuite struct {
+	dst       []byte // domain separation tag of the signatures
+	popDst    []byte // domain separation tag of the proofs of possession, ProofOfPossession only
+	augmented bool   // the messages are prefixed with the public key, MessageAugmentation only
+}
+
+var (
+	// Basic ciphersuite, aggregate signatures must be on distinct messages.
+	Basic = Ciphersuite{
+		dst: []byte("BLS_SIG_BLS12377G1_XMD:SHA-256_SSWU_RO_NUL_"),
+	}
+
+	// MessageAugmentation ciphersuite, the signed message is prefixed with the public key of the signer.
+	MessageAugmentation = Ciphersuite{
+		dst:       []byte("BLS_SIG_BLS12377G1_XMD:SHA-256_SSWU_RO_AUG_"),
+		augmented: true,
+	}
+
+	// ProofOfPossession ciphersuite, each public key must come with a proof of possession
+	// of the corresponding private key (see PopProve and PopVerify).
+	ProofOfPossession = Ciphersuite{
+		dst:    []byte("BLS_SIG_BLS12377G1_XMD:SHA-256_SSWU_RO_POP_"),
+		popDst: []byte("BLS_POP_BLS12377G1_XMD:SHA-256_SSWU_RO_POP_"),
+	}
+)
+
+// GenerateKey generates a public and private key pair, using 32 bytes read from rand as
+// input keying material for KeyGen.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// KeyGen deterministically derives a key pair from the input keying material ikm
+// (at least 32 bytes) and the optional keyInfo.
+//
+// salt = "BLS-SIG-KEYGEN-SALT-"
+// SK = 0
+// while SK == 0:
+//
+//	salt = H(salt)
+//	PRK = HKDF-Extract(salt, IKM || I2OSP(0, 1))
+//	OKM = HKDF-Expand(PRK, key_info || I2OSP(L, 2), L)
+//	SK = OS2IP(OKM) mod r
+func KeyGen(ikm, keyInfo []byte) (*PrivateKey, error) {
+	if len(ikm) < 32 {
+		return nil, ErrShortIKM
+	}
+
+	ikmPrime := make([]byte, len(ikm)+1)
+	copy(ikmPrime, ikm)
+	info := make([]byte, len(keyInfo)+2)
+	copy(info, keyInfo)
+	info[len(keyInfo)+1] = keyGenL
+
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	okm := make([]byte, keyGenL)
+	var sk big.Int
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikmPrime, salt, info), okm); err != nil {
+			return nil, err
+		}
+		sk.SetBytes(okm).Mod(&sk, fr.Modulus())
+	}
+
+	privateKey := new(PrivateKey)
+	sk.FillBytes(privateKey.scalar[:])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(&sk)
+	return privateKey, nil
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Sign signs message with the ProofOfPossession ciphersuite.
+// If hFunc is not nil, the message is first hashed with hFunc, and the digest is signed.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	message, err := preHash(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	return ProofOfPossession.Sign(privKey, message)
+}
+
+// Verify verifies a signature of message with the ProofOfPossession ciphersuite.
+// If hFunc is not nil, the message is first hashed with hFunc, as in PrivateKey.Sign.
+//
+// It is the responsibility of the caller to check the proof of possession of the public key
+// (see PopVerify) before aggregating it with other keys.
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	message, err := preHash(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+	return ProofOfPossession.Verify(pub, message, sigBin)
+}
+
+// Sign returns the signature of message.
+//
+// signature = [SK]H(message), or [SK]H(PK ‖ message) for MessageAugmentation
+func (cs Ciphersuite) Sign(privKey *PrivateKey, message []byte) ([]byte, error) {
+	if cs.augmented {
+		message = augment(&privKey.PublicKey, message)
+	}
+	return coreSign(privKey, message, cs.dst)
+}
+
+// Verify checks that sigBin is a valid signature of message by pub.
+//
+// e(PK, H(message)) ?= e(G, signature)
+func (cs Ciphersuite) Verify(pub *PublicKey, message, sigBin []byte) (bool, error) {
+	return cs.AggregateVerify([]PublicKey{*pub}, [][]byte{message}, sigBin)
+}
+
+// AggregateVerify checks that sigBin is a valid aggregated signature of messages[i] by pubs[i].
+// In the Basic ciphersuite, the messages must be distinct.
+//
+// ∏ᵢe(PKᵢ, H(messageᵢ)) ?= e(G, signature)
+func (cs Ciphersuite) AggregateVerify(pubs []PublicKey, messages [][]byte, sigBin []byte) (bool, error) {
+	if len(pubs) != len(messages) {
+		return false, ErrInvalidNbMessages
+	}
+	if len(pubs) == 0 {
+		return false, ErrEmptyAggregation
+	}
+	if !cs.augmented && cs.popDst == nil {
+		seen := make(map[string]struct{}, len(messages))
+		for i := range messages {
+			if _, ok := seen[string(messages[i])]; ok {
+				return false, ErrDuplicateMessages
+			}
+			seen[string(messages[i])] = struct{}{}
+		}
+	}
+	if cs.augmented {
+		augmented := make([][]byte, len(messages))
+		for i := range messages {
+			augmented[i] = augment(&pubs[i], messages[i])
+		}
+		messages = augmented
+	}
+	return coreAggregateVerify(pubs, messages, sigBin, cs.dst)
+}
+
+// FastAggregateVerify checks that sigBin is a valid aggregated signature of the same message by all the pubs.
+// It is only available in the ProofOfPossession ciphersuite, and the proofs of possession
+// of the public keys must have been checked beforehand.
+//
+// e(∑ᵢPKᵢ, H(message)) ?= e(G, signature)
+func (cs Ciphersuite) FastAggregateVerify(pubs []PublicKey, message, sigBin []byte) (bool, error) {
+	if cs.popDst == nil {
+		return false, ErrNotSupported
+	}
+	aggregated, err := AggregatePublicKeys(pubs)
+	if err != nil {
+		return false, err
+	}
+	return coreAggregateVerify([]PublicKey{aggregated}, [][]byte{message}, sigBin, cs.dst)
+}
+
+// PopProve returns a proof of possession of the private key, that is a signature of the
+// serialized public key with a dedicated domain separation tag.
+// It is only available in the ProofOfPossession ciphersuite.
+func (cs Ciphersuite) PopProve(privKey *PrivateKey) ([]byte, error) {
+	if cs.popDst == nil {
+		return nil, ErrNotSupported
+	}
+	return coreSign(privKey, privKey.PublicKey.Bytes(), cs.popDst)
+}
+
+// PopVerify checks a proof of possession of the private key associated to pub.
+// It is only available in the ProofOfPossession ciphersuite.
+func (cs Ciphersuite) PopVerify(pub *PublicKey, proof []byte) (bool, error) {
+	if cs.popDst == nil {
+		return false, ErrNotSupported
+	}
+	return coreAggregateVerify([]PublicKey{*pub}, [][]byte{pub.Bytes()}, proof, cs.popDst)
+}
+
+// Aggregate returns the aggregation (sum) of the signatures sigs.
+func Aggregate(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrEmptyAggregation
+	}
+	var acc bls12377.G1Jac
+	var sig Signature
+	for i := range sigs {
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return nil, err
+		}
+		acc.AddMixed(&sig.S)
+	}
+	sig.S.FromJacobian(&acc)
+	return sig.Bytes(), nil
+}
+
+// AggregatePublicKeys returns the aggregation (sum) of the public keys pubs.
+// It fails if one of the keys is invalid.
+func AggregatePublicKeys(pubs []PublicKey) (PublicKey, error) {
+	if len(pubs) == 0 {
+		return PublicKey{}, ErrEmptyAggregation
+	}
+	var acc bls12377.G2Jac
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return PublicKey{}, ErrInvalidPublicKey
+		}
+		acc.AddMixed(&pubs[i].A)
+	}
+	var res PublicKey
+	res.A.FromJacobian(&acc)
+	return res, nil
+}
+
+// coreSign returns [SK]H(message) where H hashes to G1 with the domain separation tag dst.
+func coreSign(privKey *PrivateKey, message, dst []byte) ([]byte, error) {
+	h, err := bls12377.HashToG1(message, dst)
+	if err != nil {
+		return nil, err
+	}
+	var scalar big.Int
+	scalar.SetBytes(privKey.scalar[:])
+	var sig Signature
+	sig.S.ScalarMultiplication(&h, &scalar)
+	return sig.Bytes(), nil
+}
+
+// coreAggregateVerify checks ∏ᵢe(PKᵢ, H(messageᵢ)) = e(G, signature) with a single
+// multi-pairing.
+func coreAggregateVerify(pubs []PublicKey, messages [][]byte, sigBin, dst []byte) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+
+	P := make([]bls12377.G1Affine, len(pubs)+1)
+	Q := make([]bls12377.G2Affine, len(pubs)+1)
+	for i := range pubs {
+		if !pubs[i].isValid() {
+			return false, ErrInvalidPublicKey
+		}
+		h, err := bls12377.HashToG1(messages[i], dst)
+		if err != nil {
+			return false, err
+		}
+		P[i] = h
+		Q[i] = pubs[i].A
+	}
+	_, _, _, g2 := bls12377.Generators()
+	P[len(pubs)].Neg(&sig.S)
+	Q[len(pubs)] = g2
+
+	return bls12377.PairingCheck(P, Q)
+}
+
+// isValid implements KeyValidate: the public key must not be the point at infinity and
+// must be in the prime order subgroup.
+func (pub *PublicKey) isValid() bool {
+	return !pub.A.IsInfinity() && pub.A.IsInSubGroup()
+}
+
+// augment returns PK ‖ message
+func augment(pub *PublicKey, message []byte) []byte {
+	res := make([]byte, 0, sizePublicKey+len(message))
+	res = append(res, pub.Bytes()...)
+	return append(res, message...)
+}
+
+// preHash returns hFunc(message), or message if hFunc is nil
+func preHash(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
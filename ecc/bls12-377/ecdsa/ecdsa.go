@@ -11,14 +11,14 @@ import (
 	"crypto/rand"
 	"crypto/sha512"
 	"crypto/subtle"
-	"hash"
-	"io"
-	"math/big"
-
+	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/signature"
+	"hash"
+	"io"
+	"math/big"
 )
 
 const (
@@ -30,6 +30,8 @@ const (
 	sizeSignature  = 2 * sizeFr
 )
 
+var errMismatchedLengths = errors.New("the number of public keys, messages and signatures differ")
+
 var order = fr.Modulus()
 
 // PublicKey represents an ECDSA public key
@@ -258,20 +260,9 @@ func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (boo
 
 	sInv := new(big.Int).ModInverse(s, order)
 
-	var m *big.Int
-	if hFunc != nil {
-		// compute the hash of the message as an integer
-		dataToHash := make([]byte, len(message))
-		copy(dataToHash[:], message[:])
-		hFunc.Reset()
-		_, err := hFunc.Write(dataToHash[:])
-		if err != nil {
-			return false, err
-		}
-		hramBin := hFunc.Sum(nil)
-		m = HashToInt(hramBin)
-	} else {
-		m = HashToInt(message)
+	m, err := hashMessage(message, hFunc)
+	if err != nil {
+		return false, err
 	}
 
 	u1 := new(big.Int).Mul(m, sInv)
@@ -292,3 +283,39 @@ func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (boo
 	return z.Cmp(r) == 0, nil
 
 }
+
+// BatchVerify verifies the ECDSA signatures of messages by publicKeys.
+//
+// The signatures are verified one by one, and a *signature.BatchError reports
+// the first invalid one.
+func BatchVerify(publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+	if len(messages) != len(publicKeys) || len(signatures) != len(publicKeys) {
+		return false, errMismatchedLengths
+	}
+	return verifyOneByOne(publicKeys, messages, signatures, hFunc)
+}
+
+// verifyOneByOne verifies the signatures one by one, and reports the first
+// invalid one in a *signature.BatchError.
+func verifyOneByOne(publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+	for i := range publicKeys {
+		sig := signatures[i]
+		valid, err := publicKeys[i].Verify(sig, messages[i], hFunc)
+		if !valid || err != nil {
+			return false, &signature.BatchError{Index: i, Err: err}
+		}
+	}
+	return true, nil
+}
+
+// hashMessage returns the integer of hFunc(message), or of message if hFunc is nil.
+func hashMessage(message []byte, hFunc hash.Hash) (*big.Int, error) {
+	if hFunc == nil {
+		return HashToInt(message), nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return HashToInt(hFunc.Sum(nil)), nil
+}
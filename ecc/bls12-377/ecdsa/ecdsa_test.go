@@ -8,7 +8,10 @@ package ecdsa
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/signature"
 	"math/big"
 	"testing"
 
@@ -54,6 +57,56 @@ func TestECDSA(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestBatchVerify(t *testing.T) {
+
+	const n = 10
+	hFunc := sha256.New()
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	signatures := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[i] = privKey.PublicKey
+		messages[i] = []byte(fmt.Sprintf("message %d", i))
+		signatures[i], err = privKey.Sign(messages[i], hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// verifies correct signatures
+	res, err := BatchVerify(publicKeys, messages, signatures, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("BatchVerify correct signatures should return true")
+	}
+
+	// verifies wrong msgs
+	for _, wrong := range []int{4, 5} {
+		correct := messages[wrong]
+		messages[wrong] = []byte("wrong_message")
+		res, err = BatchVerify(publicKeys, messages, signatures, hFunc)
+		if res {
+			t.Fatal("BatchVerify wrong signature should be false")
+		}
+		var batchErr *signature.BatchError
+		if !errors.As(err, &batchErr) || batchErr.Index != wrong {
+			t.Fatal("BatchVerify should report the wrong signature")
+		}
+		messages[wrong] = correct
+	}
+
+	// mismatched lengths
+	if _, err = BatchVerify(publicKeys, messages[1:], signatures, hFunc); err != errMismatchedLengths {
+		t.Fatal("BatchVerify should raise mismatched lengths error")
+	}
+}
+
 func TestNonMalleability(t *testing.T) {
 
 	// buffer too big
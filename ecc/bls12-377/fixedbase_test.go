@@ -0,0 +1,656 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpFixedBaseG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 3
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 73
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	// from the bases only to all the windows of the bases
+	pointSize := int(unsafe.Sizeof(G1Affine{}))
+	var tables []*G1FixedBaseTable
+	for _, nbPoints := range []int{nbSamples, 3 * nbSamples, 1 << 20} {
+		table, err := NewG1FixedBaseTable(samplePoints[:], nbPoints*pointSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+
+	properties.Property("[G1] MultiExpFixedBase and MultiExp should output the same result", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var scalars [nbSamples]fr.Element
+			for i := range scalars {
+				scalars[i].SetUint64(uint64(i+1)).Mul(&scalars[i], &mixer)
+			}
+			scalars[rand.N(nbSamples)].SetZero() //#nosec G404 weak rng is fine here
+
+			var expected G1Jac
+			expected.MultiExp(samplePoints[:], scalars[:], ecc.MultiExpConfig{})
+			var expectedPrefix G1Jac
+			expectedPrefix.MultiExp(samplePoints[:nbSamples/2], scalars[:nbSamples/2], ecc.MultiExpConfig{})
+
+			for _, table := range tables {
+				for _, nbTasks := range []int{1, 0} {
+					var res G1Jac
+					if _, err := res.MultiExpFixedBase(table, scalars[:], ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil || !res.Equal(&expected) {
+						return false
+					}
+					if _, err := res.MultiExpFixedBase(table, scalars[:nbSamples/2], ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil || !res.Equal(&expectedPrefix) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G1] ScalarMultiplicationFixedBase with many bases should multiply the first base", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt big.Int
+			s.BigInt(&sInt)
+			// the first base may be the point at infinity
+			var expected G1Jac
+			expected.FromAffine(&samplePoints[0])
+			expected.ScalarMultiplication(&expected, &sInt)
+			for _, table := range tables {
+				var res G1Jac
+				if !res.ScalarMultiplicationFixedBase(table, &sInt).Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases
+	var res G1Affine
+	if _, err := res.MultiExpFixedBase(tables[0], nil, ecc.MultiExpConfig{}); err != nil || !res.IsInfinity() {
+		t.Fatal("empty multi-exponentiation should output the point at infinity")
+	}
+	scalars := make([]fr.Element, nbSamples+1)
+	if _, err := res.MultiExpFixedBase(tables[0], scalars, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error with more scalars than bases")
+	}
+	if _, err := NewG1FixedBaseTable(samplePoints[:], nbSamples*pointSize-1); err == nil {
+		t.Fatal("expected an error when the bases don't fit in memory")
+	}
+	if _, err := NewG1FixedBaseTable(nil, 1<<20); err == nil {
+		t.Fatal("expected an error without bases")
+	}
+}
+
+func TestScalarMultiplicationFixedBaseG1(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// from 2-bit windows to the widest ones
+	var tables []*G1FixedBaseTable
+	for _, c := range []uint64{2, 5, 8} {
+		table := &G1FixedBaseTable{nbBases: 1, c: c, stride: 1}
+		table.initSingleBase(&g1GenAff)
+		tables = append(tables, table)
+	}
+	table, err := NewG1FixedBaseTable([]G1Affine{g1GenAff}, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables = append(tables, table)
+
+	properties.Property("[G1] ScalarMultiplicationFixedBase and ScalarMultiplication should output the same result", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt, sNeg, sLarge big.Int
+			s.BigInt(&sInt)
+			sNeg.Neg(&sInt)
+			sLarge.Add(&sInt, fr.Modulus())
+
+			var expected, expectedNeg G1Jac
+			expected.ScalarMultiplication(&g1Gen, &sInt)
+			expectedNeg.Neg(&expected)
+
+			for _, table := range tables {
+				var res G1Jac
+				if !res.ScalarMultiplicationFixedBase(table, &sInt).Equal(&expected) {
+					return false
+				}
+				if !res.ScalarMultiplicationFixedBase(table, &sNeg).Equal(&expectedNeg) {
+					return false
+				}
+				if !res.ScalarMultiplicationFixedBase(table, &sLarge).Equal(&expected) {
+					return false
+				}
+				var resMsm G1Jac
+				if _, err := resMsm.MultiExpFixedBase(table, []fr.Element{s}, ecc.MultiExpConfig{}); err != nil || !resMsm.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases
+	var res G1Affine
+	for _, s := range []*big.Int{big.NewInt(0), fr.Modulus()} {
+		if !res.ScalarMultiplicationFixedBase(tables[0], s).IsInfinity() {
+			t.Fatal("expected the point at infinity")
+		}
+	}
+	var infinity G1Affine
+	table, err = NewG1FixedBaseTable([]G1Affine{infinity}, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.ScalarMultiplicationFixedBase(table, big.NewInt(42)).IsInfinity() {
+		t.Fatal("expected the point at infinity")
+	}
+}
+
+func TestG1FixedBaseTableSerialization(t *testing.T) {
+	t.Parallel()
+
+	const nbSamples = 11
+	var samplePoints [nbSamples]G1Affine
+	var s big.Int
+	for i := range samplePoints {
+		s.SetUint64(uint64(i*i + 3))
+		samplePoints[i].ScalarMultiplicationBase(&s)
+	}
+	samplePoints[3].SetInfinity()
+
+	multiBase, err := NewG1FixedBaseTable(samplePoints[:], 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	singleBase, err := NewG1FixedBaseTable(samplePoints[:1], 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range []*G1FixedBaseTable{multiBase, singleBase} {
+		var buf bytes.Buffer
+		written, err := table.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != int64(buf.Len()) {
+			t.Fatal("WriteTo returned a wrong number of bytes")
+		}
+		encoded := buf.Bytes()
+
+		for _, readFrom := range []func(*G1FixedBaseTable, []byte) (int64, error){
+			func(table *G1FixedBaseTable, b []byte) (int64, error) { return table.ReadFrom(bytes.NewReader(b)) },
+			func(table *G1FixedBaseTable, b []byte) (int64, error) {
+				return table.UnsafeReadFrom(bytes.NewReader(b))
+			},
+		} {
+			var decoded G1FixedBaseTable
+			read, err := readFrom(&decoded, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if read != written {
+				t.Fatal("ReadFrom returned a wrong number of bytes")
+			}
+			if decoded.nbBases != table.nbBases || decoded.c != table.c || decoded.stride != table.stride || len(decoded.points) != len(table.points) {
+				t.Fatal("decoded table doesn't match")
+			}
+			for i := range table.points {
+				if !decoded.points[i].Equal(&table.points[i]) {
+					t.Fatalf("point %d of the decoded table doesn't match", i)
+				}
+			}
+
+			// truncated input
+			if _, err := readFrom(&decoded, encoded[:len(encoded)-1]); err == nil {
+				t.Fatal("expected an error on truncated input")
+			}
+		}
+	}
+
+	// invalid headers
+	for _, header := range [][3]uint64{
+		{0, 8, 1},
+		{nbSamples, 1, 1},
+		{nbSamples, 17, 1},
+		{nbSamples, 8, 0},
+		{nbSamples, 8, 1 << 20},
+		{1, 8, 2},
+		{1 << 62, 4, 1},
+	} {
+		var buf bytes.Buffer
+		table := G1FixedBaseTable{nbBases: int(header[0]), c: header[1], stride: header[2]}
+		if _, err := table.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := table.ReadFrom(&buf); err != errInvalidFixedBaseTable {
+			t.Fatalf("header %v: expected an invalid table error, got %v", header, err)
+		}
+	}
+}
+
+func BenchmarkMultiExpFixedBaseG1(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G1Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG1(samplePoints[:])
+
+	pointSize := int(unsafe.Sizeof(G1Affine{}))
+	var testPoint G1Affine
+
+	b.Run("MultiExp", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			testPoint.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+		}
+	})
+
+	for _, factor := range []int{1, 4, 16} {
+		table, err := NewG1FixedBaseTable(samplePoints[:], factor*nbSamples*pointSize)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("memory=%dx", factor), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpFixedBase(table, sampleScalars[:], ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
+
+func BenchmarkScalarMultiplicationFixedBaseG1(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var sInt big.Int
+	s.BigInt(&sInt)
+
+	var p G1Jac
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplication(&g1Gen, &sInt)
+		}
+	})
+	b.Run("ScalarMultiplicationBase", func(b *testing.B) {
+		p.ScalarMultiplicationBase(&sInt)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplicationBase(&sInt)
+		}
+	})
+}
+
+func TestMultiExpFixedBaseG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 3
+	} else {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	const nbSamples = 73
+	var samplePoints [nbSamples]G2Affine
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+	samplePoints[rand.N(nbSamples)].SetInfinity() //#nosec G404 weak rng is fine here
+
+	// from the bases only to all the windows of the bases
+	pointSize := int(unsafe.Sizeof(G2Affine{}))
+	var tables []*G2FixedBaseTable
+	for _, nbPoints := range []int{nbSamples, 3 * nbSamples, 1 << 20} {
+		table, err := NewG2FixedBaseTable(samplePoints[:], nbPoints*pointSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+
+	properties.Property("[G2] MultiExpFixedBase and MultiExp should output the same result", prop.ForAll(
+		func(mixer fr.Element) bool {
+			var scalars [nbSamples]fr.Element
+			for i := range scalars {
+				scalars[i].SetUint64(uint64(i+1)).Mul(&scalars[i], &mixer)
+			}
+			scalars[rand.N(nbSamples)].SetZero() //#nosec G404 weak rng is fine here
+
+			var expected G2Jac
+			expected.MultiExp(samplePoints[:], scalars[:], ecc.MultiExpConfig{})
+			var expectedPrefix G2Jac
+			expectedPrefix.MultiExp(samplePoints[:nbSamples/2], scalars[:nbSamples/2], ecc.MultiExpConfig{})
+
+			for _, table := range tables {
+				for _, nbTasks := range []int{1, 0} {
+					var res G2Jac
+					if _, err := res.MultiExpFixedBase(table, scalars[:], ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil || !res.Equal(&expected) {
+						return false
+					}
+					if _, err := res.MultiExpFixedBase(table, scalars[:nbSamples/2], ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil || !res.Equal(&expectedPrefix) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G2] ScalarMultiplicationFixedBase with many bases should multiply the first base", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt big.Int
+			s.BigInt(&sInt)
+			// the first base may be the point at infinity
+			var expected G2Jac
+			expected.FromAffine(&samplePoints[0])
+			expected.ScalarMultiplication(&expected, &sInt)
+			for _, table := range tables {
+				var res G2Jac
+				if !res.ScalarMultiplicationFixedBase(table, &sInt).Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases
+	var res G2Affine
+	if _, err := res.MultiExpFixedBase(tables[0], nil, ecc.MultiExpConfig{}); err != nil || !res.IsInfinity() {
+		t.Fatal("empty multi-exponentiation should output the point at infinity")
+	}
+	scalars := make([]fr.Element, nbSamples+1)
+	if _, err := res.MultiExpFixedBase(tables[0], scalars, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error with more scalars than bases")
+	}
+	if _, err := NewG2FixedBaseTable(samplePoints[:], nbSamples*pointSize-1); err == nil {
+		t.Fatal("expected an error when the bases don't fit in memory")
+	}
+	if _, err := NewG2FixedBaseTable(nil, 1<<20); err == nil {
+		t.Fatal("expected an error without bases")
+	}
+}
+
+func TestScalarMultiplicationFixedBaseG2(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// from 2-bit windows to the widest ones
+	var tables []*G2FixedBaseTable
+	for _, c := range []uint64{2, 5, 8} {
+		table := &G2FixedBaseTable{nbBases: 1, c: c, stride: 1}
+		table.initSingleBase(&g2GenAff)
+		tables = append(tables, table)
+	}
+	table, err := NewG2FixedBaseTable([]G2Affine{g2GenAff}, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables = append(tables, table)
+
+	properties.Property("[G2] ScalarMultiplicationFixedBase and ScalarMultiplication should output the same result", prop.ForAll(
+		func(s fr.Element) bool {
+			var sInt, sNeg, sLarge big.Int
+			s.BigInt(&sInt)
+			sNeg.Neg(&sInt)
+			sLarge.Add(&sInt, fr.Modulus())
+
+			var expected, expectedNeg G2Jac
+			expected.ScalarMultiplication(&g2Gen, &sInt)
+			expectedNeg.Neg(&expected)
+
+			for _, table := range tables {
+				var res G2Jac
+				if !res.ScalarMultiplicationFixedBase(table, &sInt).Equal(&expected) {
+					return false
+				}
+				if !res.ScalarMultiplicationFixedBase(table, &sNeg).Equal(&expectedNeg) {
+					return false
+				}
+				if !res.ScalarMultiplicationFixedBase(table, &sLarge).Equal(&expected) {
+					return false
+				}
+				var resMsm G2Jac
+				if _, err := resMsm.MultiExpFixedBase(table, []fr.Element{s}, ecc.MultiExpConfig{}); err != nil || !resMsm.Equal(&expected) {
+					return false
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// edge cases
+	var res G2Affine
+	for _, s := range []*big.Int{big.NewInt(0), fr.Modulus()} {
+		if !res.ScalarMultiplicationFixedBase(tables[0], s).IsInfinity() {
+			t.Fatal("expected the point at infinity")
+		}
+	}
+	var infinity G2Affine
+	table, err = NewG2FixedBaseTable([]G2Affine{infinity}, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.ScalarMultiplicationFixedBase(table, big.NewInt(42)).IsInfinity() {
+		t.Fatal("expected the point at infinity")
+	}
+}
+
+func TestG2FixedBaseTableSerialization(t *testing.T) {
+	t.Parallel()
+
+	const nbSamples = 11
+	var samplePoints [nbSamples]G2Affine
+	var s big.Int
+	for i := range samplePoints {
+		s.SetUint64(uint64(i*i + 3))
+		samplePoints[i].ScalarMultiplicationBase(&s)
+	}
+	samplePoints[3].SetInfinity()
+
+	multiBase, err := NewG2FixedBaseTable(samplePoints[:], 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	singleBase, err := NewG2FixedBaseTable(samplePoints[:1], 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, table := range []*G2FixedBaseTable{multiBase, singleBase} {
+		var buf bytes.Buffer
+		written, err := table.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != int64(buf.Len()) {
+			t.Fatal("WriteTo returned a wrong number of bytes")
+		}
+		encoded := buf.Bytes()
+
+		for _, readFrom := range []func(*G2FixedBaseTable, []byte) (int64, error){
+			func(table *G2FixedBaseTable, b []byte) (int64, error) { return table.ReadFrom(bytes.NewReader(b)) },
+			func(table *G2FixedBaseTable, b []byte) (int64, error) {
+				return table.UnsafeReadFrom(bytes.NewReader(b))
+			},
+		} {
+			var decoded G2FixedBaseTable
+			read, err := readFrom(&decoded, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if read != written {
+				t.Fatal("ReadFrom returned a wrong number of bytes")
+			}
+			if decoded.nbBases != table.nbBases || decoded.c != table.c || decoded.stride != table.stride || len(decoded.points) != len(table.points) {
+				t.Fatal("decoded table doesn't match")
+			}
+			for i := range table.points {
+				if !decoded.points[i].Equal(&table.points[i]) {
+					t.Fatalf("point %d of the decoded table doesn't match", i)
+				}
+			}
+
+			// truncated input
+			if _, err := readFrom(&decoded, encoded[:len(encoded)-1]); err == nil {
+				t.Fatal("expected an error on truncated input")
+			}
+		}
+	}
+
+	// invalid headers
+	for _, header := range [][3]uint64{
+		{0, 8, 1},
+		{nbSamples, 1, 1},
+		{nbSamples, 17, 1},
+		{nbSamples, 8, 0},
+		{nbSamples, 8, 1 << 20},
+		{1, 8, 2},
+		{1 << 62, 4, 1},
+	} {
+		var buf bytes.Buffer
+		table := G2FixedBaseTable{nbBases: int(header[0]), c: header[1], stride: header[2]}
+		if _, err := table.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := table.ReadFrom(&buf); err != errInvalidFixedBaseTable {
+			t.Fatalf("header %v: expected an invalid table error, got %v", header, err)
+		}
+	}
+}
+
+func BenchmarkMultiExpFixedBaseG2(b *testing.B) {
+	const nbSamples = 1 << 16
+
+	var (
+		samplePoints  [nbSamples]G2Affine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	fillBenchScalars(sampleScalars[:])
+	fillBenchBasesG2(samplePoints[:])
+
+	pointSize := int(unsafe.Sizeof(G2Affine{}))
+	var testPoint G2Affine
+
+	b.Run("MultiExp", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			testPoint.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+		}
+	})
+
+	for _, factor := range []int{1, 4, 16} {
+		table, err := NewG2FixedBaseTable(samplePoints[:], factor*nbSamples*pointSize)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("memory=%dx", factor), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpFixedBase(table, sampleScalars[:], ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
+
+func BenchmarkScalarMultiplicationFixedBaseG2(b *testing.B) {
+	var s fr.Element
+	s.SetRandom()
+	var sInt big.Int
+	s.BigInt(&sInt)
+
+	var p G2Jac
+	b.Run("ScalarMultiplication", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplication(&g2Gen, &sInt)
+		}
+	})
+	b.Run("ScalarMultiplicationBase", func(b *testing.B) {
+		p.ScalarMultiplicationBase(&sInt)
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			p.ScalarMultiplicationBase(&sInt)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// BatchProofOfProximity proof of proximity of several polynomials of possibly
+// different sizes.
+//
+// The polynomials pᵢ, of sizes dᵢ, are committed in a single Merkle tree. The
+// proof of proximity is then made for the random linear combination
+// ∑ᵢ (γ²ⁱ + γ²ⁱ⁺¹X^{n-dᵢ}) pᵢ, where n is the size of the IOPP. The shifts
+// X^{n-dᵢ} ensure that each pᵢ is close to a polynomial of size dᵢ, and not
+// only n.
+type BatchProofOfProximity struct {
+
+	// MerkleRoot root of the Merkle tree committing to the polynomials. Its
+	// leaves are the concatenations, for each polynomial, of its evaluations
+	// on the cosets of the first folding step (see Round).
+	MerkleRoot []byte
+
+	// Proof multiproof of the leaves opened by the queries of the verifier
+	Proof merkletree.MultiProof
+
+	// ProofOfProximity proof of proximity of the linear combination
+	ProofOfProximity ProofOfProximity
+}
+
+// bindBatch binds the commitment to the polynomials and their sizes, and
+// derives the challenge γ of the linear combination.
+func bindBatch(fs *fiatshamir.Transcript, root []byte, sizes []int) (fr.Element, error) {
+	var gamma fr.Element
+	if err := fs.Bind("gamma", root); err != nil {
+		return gamma, err
+	}
+	var buf [8]byte
+	for _, size := range sizes {
+		binary.BigEndian.PutUint64(buf[:], uint64(size))
+		if err := fs.Bind("gamma", buf[:]); err != nil {
+			return gamma, err
+		}
+	}
+	bGamma, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return gamma, err
+	}
+	gamma.SetBytes(bGamma)
+	return gamma, nil
+}
+
+// combineAt returns ∑ᵢ (γ²ⁱ + γ²ⁱ⁺¹x^{n-dᵢ}) vᵢ, where vᵢ is the evaluation at
+// x of the i-th polynomial.
+func (s radixTwoFri) combineAt(v []fr.Element, x, gamma fr.Element, sizes []int) fr.Element {
+	var res, coeff, shift, acc fr.Element
+	acc.SetOne()
+	for i := range v {
+		shift.Exp(x, new(big.Int).SetUint64(s.size-uint64(sizes[i])))
+		coeff.Mul(&acc, &gamma)
+		shift.Mul(&shift, &coeff).Add(&shift, &acc)
+		shift.Mul(&shift, &v[i])
+		res.Add(&res, &shift)
+		acc.Mul(&coeff, &gamma)
+	}
+	return res
+}
+
+// BuildBatchProofOfProximity generates a proof that each polynomial of ps, as
+// an oracle from the verifier point of view, is δ-close to a polynomial of
+// size len(ps[i]).
+func (s radixTwoFri) BuildBatchProofOfProximity(ps [][]fr.Element) (BatchProofOfProximity, error) {
+
+	var proof BatchProofOfProximity
+	if len(ps) == 0 {
+		return proof, ErrEmptyBatch
+	}
+	sizes := make([]int, len(ps))
+	evaluations := make([][]fr.Element, len(ps))
+	for i := range ps {
+		if uint64(len(ps[i])) > s.size {
+			return proof, ErrPolynomialSize
+		}
+		sizes[i] = len(ps[i])
+		evaluations[i] = s.evaluate(ps[i])
+	}
+
+	// commit to the polynomials
+	leaves := make([][][]byte, len(ps))
+	for i := range evaluations {
+		leaves[i] = cosetLeaves(evaluations[i], s.foldingFactors[0])
+	}
+	for l := range leaves[0] {
+		for i := 1; i < len(leaves); i++ {
+			leaves[0][l] = append(leaves[0][l], leaves[i][l]...)
+		}
+	}
+	tree, err := merkletree.NewFullTree(s.h, leaves[0])
+	if err != nil {
+		return proof, err
+	}
+	proof.MerkleRoot = tree.Root()
+
+	fs := fiatshamir.NewTranscript(s.h, s.challengeNames(true)...)
+	gamma, err := bindBatch(fs, proof.MerkleRoot, sizes)
+	if err != nil {
+		return proof, err
+	}
+
+	// evaluations of the linear combination
+	combination := make([]fr.Element, s.domain.Cardinality)
+	v := make([]fr.Element, len(ps))
+	var x fr.Element
+	x.SetOne()
+	for m := range combination {
+		for i := range evaluations {
+			v[i].Set(&evaluations[i][m])
+		}
+		combination[m] = s.combineAt(v, x, gamma, sizes)
+		x.Mul(&x, &s.domain.Generator)
+	}
+
+	var queries []uint64
+	proof.ProofOfProximity, queries, err = s.prove(fs, combination)
+	if err != nil {
+		return proof, err
+	}
+
+	// open the queried leaves of the polynomials
+	for j := range queries {
+		queries[j] %= tree.NumLeaves()
+	}
+	proof.Proof, err = tree.ProveMulti(queries)
+	return proof, err
+}
+
+// VerifyBatchProofOfProximity verifies a batched proof of proximity of
+// polynomials of the given sizes.
+func (s radixTwoFri) VerifyBatchProofOfProximity(sizes []int, proof BatchProofOfProximity) error {
+
+	if len(sizes) == 0 {
+		return ErrMalformedProof
+	}
+	for _, size := range sizes {
+		if size < 0 || uint64(size) > s.size {
+			return ErrPolynomialSize
+		}
+	}
+
+	fs := fiatshamir.NewTranscript(s.h, s.challengeNames(true)...)
+	gamma, err := bindBatch(fs, proof.MerkleRoot, sizes)
+	if err != nil {
+		return err
+	}
+
+	queries, firstCosets, err := s.verify(fs, proof.ProofOfProximity)
+	if err != nil {
+		return err
+	}
+
+	// the opened cosets of the linear combination should match the ones
+	// computed from the polynomials
+	k := s.foldingFactors[0]
+	n := s.domain.Cardinality / k
+	leaves, err := s.openedCosets(proof.MerkleRoot, proof.Proof, queries, n, k*uint64(len(sizes)))
+	if err != nil {
+		return err
+	}
+	v := make([]fr.Element, len(sizes))
+	for l, leaf := range leaves {
+		for t := uint64(0); t < k; t++ {
+			for i := range v {
+				v[i].Set(&leaf[uint64(i)*k+t])
+			}
+			var x fr.Element
+			x.Exp(s.domain.Generator, new(big.Int).SetUint64(l+t*n))
+			c := s.combineAt(v, x, gamma, sizes)
+			if !c.Equal(&firstCosets[l][t]) {
+				return ErrProximityTestFolding
+			}
+		}
+	}
+	return nil
+}
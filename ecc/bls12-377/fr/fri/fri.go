@@ -7,11 +7,13 @@ package fri
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
 	"math/big"
 	"math/bits"
+	"slices"
 
 	"github.com/consensys/gnark-crypto/accumulator/merkletree"
 	"github.com/consensys/gnark-crypto/ecc"
@@ -21,54 +23,37 @@ import (
 )
 
 var (
-	ErrLowDegree            = errors.New("the fully folded polynomial in not of degree 1")
+	ErrLowDegree            = errors.New("the fully folded polynomial does not match the final polynomial")
 	ErrProximityTestFolding = errors.New("one round of interaction failed")
 	ErrOddSize              = errors.New("the size should be even")
 	ErrMerkleRoot           = errors.New("merkle roots of the opening and the proof of proximity don't coincide")
 	ErrMerklePath           = errors.New("merkle path proof is wrong")
 	ErrRangePosition        = errors.New("the asked opening position is out of range")
+	ErrClaimedValue         = errors.New("the claimed value does not match the opened leaf")
+	ErrProofOfWork          = errors.New("the proof of work is invalid")
+	ErrPolynomialSize       = errors.New("the polynomial is larger than the size of the IOPP")
+	ErrMalformedProof       = errors.New("the proof does not match the parameters of the IOPP")
+	ErrEmptyBatch           = errors.New("the batch does not contain any polynomial")
 )
 
+// rho is the default blowup factor.
 const rho = 8
 
-const nbRounds = 1
-
-// 2^{-1}, used several times
-var twoInv fr.Element
-
 // Digest commitment of a polynomial.
 type Digest []byte
 
-// merkleProof helper structure to build the merkle proof
-// At each round, two contiguous values from the evaluated polynomial
-// are queried. For one value, the full Merkle path will be provided.
-// For the neighbor value, only the leaf is provided (so ProofSet will
-// be empty), since the Merkle path is the same as for the first value.
-type MerkleProof struct {
-
-	// Merkle root
-	MerkleRoot []byte
-
-	// ProofSet stores [leaf ∥ node_1 ∥ .. ∥ merkleRoot ], where the leaf is not
-	// hashed.
-	ProofSet [][]byte
-
-	// number of leaves of the tree.
-	numLeaves uint64
-}
-
 // MerkleProof used to open a polynomial
 type OpeningProof struct {
 
-	// those fields are private since they are only needed for
-	// the verification, which is abstracted in the VerifyOpening
-	// method.
-	merkleRoot []byte
+	// Merkle path proof of the leaf containing the opened entry, as returned
+	// by merkletree.Tree.Prove. Those fields are only needed for the
+	// verification, which is abstracted in the VerifyOpening method.
+	MerkleRoot []byte
 	ProofSet   [][]byte
-	numLeaves  uint64
-	index      uint64
+	NumLeaves  uint64
+	Index      uint64
 
-	// ClaimedValue value of the leaf. This field is exported
+	// ClaimedValue value of the opened entry. This field is exported
 	// because it's needed for protocols using polynomial commitment
 	// schemes (to verify an algebraic relation).
 	ClaimedValue fr.Element
@@ -78,38 +63,31 @@ type OpeningProof struct {
 type IOPP uint
 
 const (
-	// Multiplicative version of FRI, using the map x->x², on a
-	// power of 2 subgroup of Fr^{*}.
+	// Multiplicative version of FRI, using the maps x->xᵏ for k a power of 2,
+	// on a power of 2 subgroup of Fr^{*}.
 	RADIX_2_FRI IOPP = iota
 )
 
-// round contains the data corresponding to a single round
-// of fri.
-// It consists of a list of Interactions between the prover and the verifier,
-// where each interaction contains a challenge provided by the verifier, as
-// well as MerkleProofs for the queries of the verifier. The Merkle proofs
-// correspond to the openings of the i-th folded polynomial at 2 points that
-// belong to the same fiber of x -> x².
+// Round contains the data corresponding to a single folding step of fri.
+//
+// The oracle of the step is committed in a Merkle tree whose leaves are the
+// cosets of the k-th roots of unity, where k is the folding factor of the step:
+// the leaf l of an oracle of size n is the concatenation of the evaluations at
+// g^{l}, g^{l+n/k}, …, g^{l+(k-1)n/k}. Those are the k entries folded into one.
 type Round struct {
 
-	// stores the Interactions between the prover and the verifier.
-	// Each interaction results in a set or merkle proofs, corresponding
-	// to the queries of the verifier.
-	Interactions [][2]MerkleProof
-
-	// evaluation stores the evaluation of the fully folded polynomial.
-	// The fully folded polynomial is constant, and is evaluated on a
-	// a set of size \rho. Since the polynomial is supposed to be constant,
-	// only one evaluation, corresponding to the polynomial, is given. Since
-	// the prover cannot know in advance which entry the verifier will query,
-	// providing a single evaluation
-	Evaluation fr.Element
+	// MerkleRoot root of the Merkle tree of the oracle
+	MerkleRoot []byte
+
+	// Proof multiproof of the leaves opened by the queries of the verifier
+	Proof merkletree.MultiProof
 }
 
 // ProofOfProximity proof of proximity, attesting that
 // a function is d-close to a low degree polynomial.
 //
-// It is composed of a series of Interactions, emulated with Fiat Shamir,
+// It is composed of a series of folding steps, emulated with Fiat Shamir,
+// followed by the queries of the verifier.
 type ProofOfProximity struct {
 
 	// ID unique ID attached to the proof of proximity. It's needed for
@@ -117,9 +95,15 @@ type ProofOfProximity struct {
 	// from the proof of proximity.
 	ID []byte
 
-	// round contains the data corresponding to a single round
-	// of fri. There are nbRounds rounds of Interactions.
+	// Rounds contains the commitments and openings of each folding step.
 	Rounds []Round
+
+	// FinalPolynomial coefficients of the fully folded polynomial, in
+	// canonical basis.
+	FinalPolynomial []fr.Element
+
+	// Nonce proof of work preceding the derivation of the queries.
+	Nonce uint64
 }
 
 // Iopp interface that an iopp should implement
@@ -133,6 +117,14 @@ type Iopp interface {
 	// verification fails.
 	VerifyProofOfProximity(proof ProofOfProximity) error
 
+	// BuildBatchProofOfProximity creates a proof of proximity for each polynomial of ps,
+	// which may have different sizes, at the cost of a single one.
+	BuildBatchProofOfProximity(ps [][]fr.Element) (BatchProofOfProximity, error)
+
+	// VerifyBatchProofOfProximity verifies a batched proof of proximity of polynomials
+	// of the given sizes.
+	VerifyBatchProofOfProximity(sizes []int, proof BatchProofOfProximity) error
+
 	// Opens a polynomial at gⁱ where i = position.
 	Open(p []fr.Element, position uint64) (OpeningProof, error)
 
@@ -140,548 +132,522 @@ type Iopp interface {
 	VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error
 }
 
-// GetRho returns the factor ρ = size_code_word/size_polynomial
+// GetRho returns the default factor ρ = size_code_word/size_polynomial
 func GetRho() int {
 	return rho
 }
 
-func init() {
-	twoInv.SetUint64(2).Inverse(&twoInv)
-}
-
 // New creates a new IOPP capable to handle degree(size) polynomials.
-func (iopp IOPP) New(size uint64, h hash.Hash) Iopp {
+// It panics if the options are invalid.
+func (iopp IOPP) New(size uint64, h hash.Hash, options ...Option) Iopp {
 	switch iopp {
 	case RADIX_2_FRI:
-		return newRadixTwoFri(size, h)
+		return newRadixTwoFri(size, h, options...)
 	default:
 		panic("iopp name is not recognized")
 	}
 }
 
-// radixTwoFri empty structs implementing compressionFunction for
-// the squaring function.
+// radixTwoFri implements FRI with folding factors powers of 2.
 type radixTwoFri struct {
 
 	// hash function that is used for Fiat Shamir and for committing to
 	// the oracles.
 	h hash.Hash
 
-	// nbSteps number of Interactions between the prover and the verifier
-	nbSteps int
+	// size bound on the number of coefficients of the polynomials, a power of 2
+	size uint64
 
 	// domain used to build the Reed Solomon code from the given polynomial.
-	// The size of the domain is ρ*size_polynomial.
+	// The size of the domain is ρ*size.
 	domain *fft.Domain
-}
 
-func newRadixTwoFri(size uint64, h hash.Hash) radixTwoFri {
+	// foldingFactors folding factor of each step
+	foldingFactors []uint64
 
-	var res radixTwoFri
+	// finalSize number of coefficients of the final polynomial
+	finalSize uint64
 
-	// computing the number of steps
-	n := ecc.NextPowerOfTwo(size)
-	nbSteps := bits.TrailingZeros(uint(n))
-	res.nbSteps = nbSteps
+	// nbQueries number of queries of the verifier
+	nbQueries int
 
-	// extending the domain
-	n = n * rho
+	// grindingBits number of bits of the proof of work
+	grindingBits int
+}
 
-	// building the domains
-	res.domain = fft.NewDomain(n)
+func newRadixTwoFri(size uint64, h hash.Hash, options ...Option) radixTwoFri {
 
-	// hash function
+	cfg := defaultConfig()
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.blowupFactor < 2 || bits.OnesCount64(cfg.blowupFactor) != 1 {
+		panic("the blowup factor should be a power of 2, at least 2")
+	}
+	switch cfg.foldingFactor {
+	case 2, 4, 8, 16:
+	default:
+		panic("the folding factor should be 2, 4, 8 or 16")
+	}
+	if cfg.nbQueries < 1 {
+		panic("the number of queries should be positive")
+	}
+	if cfg.grindingBits < 0 || cfg.grindingBits > 64 {
+		panic("the number of grinding bits should be between 0 and 64")
+	}
+
+	var res radixTwoFri
 	res.h = h
+	res.nbQueries = cfg.nbQueries
+	res.grindingBits = cfg.grindingBits
+
+	// at least one folding step is made
+	res.size = ecc.NextPowerOfTwo(max(size, 2))
+	res.finalSize = min(ecc.NextPowerOfTwo(cfg.finalDegree+1), res.size/2)
+
+	// the last step folds by a smaller factor if needed to reach the final size
+	for n := res.size; n > res.finalSize; {
+		k := min(cfg.foldingFactor, n/res.finalSize)
+		res.foldingFactors = append(res.foldingFactors, k)
+		n /= k
+	}
+
+	// extending the domain
+	res.domain = fft.NewDomain(res.size * cfg.blowupFactor)
 
 	return res
 }
 
-// convertCanonicalSorted convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertCanonicalSorted(i, n int) int {
-
-	if i < n/2 {
-		return 2 * i
-	} else {
-		l := n - (i + 1)
-		l = 2 * l
-		return n - l - 1
+// challengeNames returns the names of the Fiat Shamir challenges: the folding
+// challenges xᵢ, the seed of the proof of work and the seed of the queries.
+// A batched proof first derives the challenge γ of the linear combination.
+func (s radixTwoFri) challengeNames(batch bool) []string {
+	var res []string
+	if batch {
+		res = append(res, "gamma")
 	}
-
-}
-
-// deriveQueriesPositions derives the indices of the oracle
-// function that the verifier has to pick, in sorted form.
-// * pos is the initial position, i.e. the logarithm of the first challenge
-// * size is the size of the initial polynomial
-// * The result is a slice of []int, where each entry is a tuple (iₖ), such that
-// the verifier needs to evaluate ∑ₖ oracle(iₖ)xᵏ to build
-// the folded function.
-func (s radixTwoFri) deriveQueriesPositions(pos int, size int) []int {
-
-	_s := size / 2
-	res := make([]int, s.nbSteps)
-	res[0] = pos
-	for i := 1; i < s.nbSteps; i++ {
-		t := (res[i-1] - (res[i-1] % 2)) / 2
-		res[i] = convertCanonicalSorted(t, _s)
-		_s = _s / 2
+	for i := range s.foldingFactors {
+		res = append(res, fmt.Sprintf("x%d", i))
 	}
+	return append(res, "grinding", "queries")
+}
 
+// evaluate returns the evaluations of p on s.domain, in natural order.
+func (s radixTwoFri) evaluate(p []fr.Element) []fr.Element {
+	res := make([]fr.Element, s.domain.Cardinality)
+	copy(res, p)
+	s.domain.FFT(res, fft.DIF)
+	fft.BitReverse(res)
 	return res
 }
 
-// sort orders the evaluation of a polynomial on a domain
-// such that contiguous entries are in the same fiber:
-// {q(g⁰), q(g^{n/2}), q(g¹), q(g^{1+n/2}),...,q(g^{n/2-1}), q(gⁿ⁻¹)}
-func sort(evaluations []fr.Element) []fr.Element {
-	q := make([]fr.Element, len(evaluations))
-	n := len(evaluations) / 2
-	for i := 0; i < n; i++ {
-		q[2*i].Set(&evaluations[i])
-		q[2*i+1].Set(&evaluations[i+n])
-	}
-	return q
+// cosetLeaves returns the leaves of the Merkle tree of the evaluations p, in
+// natural order, for the folding factor k: leaf l is the concatenation of
+// p[l], p[l+n/k], …, p[l+(k-1)n/k], where n = len(p).
+func cosetLeaves(p []fr.Element, k uint64) [][]byte {
+	n := uint64(len(p)) / k
+	res := make([][]byte, n)
+	for l := range res {
+		res[l] = make([]byte, 0, k*fr.Bytes)
+		for t := uint64(0); t < k; t++ {
+			b := p[uint64(l)+t*n].Bytes()
+			res[l] = append(res[l], b[:]...)
+		}
+	}
+	return res
 }
 
-// Opens a polynomial at gⁱ where i = position.
-func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error) {
-
-	// check that position is in the correct range
-	if position >= s.domain.Cardinality {
-		return OpeningProof{}, ErrRangePosition
+// decodeCoset decodes a leaf built by cosetLeaves, of k entries.
+func decodeCoset(leaf []byte, k uint64) ([]fr.Element, error) {
+	if uint64(len(leaf)) != k*fr.Bytes {
+		return nil, ErrMalformedProof
 	}
-
-	// put q in evaluation form
-	q := make([]fr.Element, s.domain.Cardinality)
-	copy(q, p)
-	s.domain.FFT(q, fft.DIF)
-	fft.BitReverse(q)
-
-	// sort q to have fibers in contiguous entries. The goal is to have one
-	// Merkle path for both openings of entries which are in the same fiber.
-	q = sort(q)
-
-	// build the Merkle proof, we the position is converted to fit the sorted polynomial
-	pos := convertCanonicalSorted(int(position), len(q))
-
-	tree := merkletree.New(s.h)
-	err := tree.SetIndex(uint64(pos))
-	if err != nil {
-		return OpeningProof{}, err
-	}
-	for i := 0; i < len(q); i++ {
-		tree.Push(q[i].Marshal())
+	res := make([]fr.Element, k)
+	for t := range res {
+		if err := res[t].SetBytesCanonical(leaf[t*fr.Bytes : (t+1)*fr.Bytes]); err != nil {
+			return nil, ErrMalformedProof
+		}
 	}
-	var res OpeningProof
-	res.merkleRoot, res.ProofSet, res.index, res.numLeaves = tree.Prove()
-
-	// set the claimed value, which is the first entry of the Merkle proof
-	res.ClaimedValue.SetBytes(res.ProofSet[0])
-
 	return res, nil
 }
 
-// Verifies the opening of a polynomial.
-// * position the point at which the proof is opened (the point is gⁱ where i = position)
-// * openingProof Merkle path proof
-// * pp proof of proximity, needed because before opening Merkle path proof one should be sure that the
-// committed values come from a polynomial. During the verification of the Merkle path proof, the root
-// hash of the Merkle path is compared to the root hash of the first interaction of the proof of proximity,
-// those should be equal, if not an error is raised.
-func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error {
-
-	// To query the Merkle path, we look at the first series of Interactions, and check whether it's the point
-	// at 'position' or its neighbor that contains the full Merkle path.
-	var fullMerkleProof int
-	if len(pp.Rounds[0].Interactions[0][0].ProofSet) > len(pp.Rounds[0].Interactions[0][1].ProofSet) {
-		fullMerkleProof = 0
-	} else {
-		fullMerkleProof = 1
+// foldingConstants returns the constants used to fold by a factor k the
+// evaluations of a polynomial on the subgroup of size n generated by g: the
+// powers ω⁻ᵗ, t<k, of the inverse of the k-th root of unity ω = g^{n/k},
+// and k⁻¹.
+func foldingConstants(gInv fr.Element, n, k uint64) ([]fr.Element, fr.Element) {
+	var omegaInv, kInv fr.Element
+	omegaInv.Exp(gInv, new(big.Int).SetUint64(n/k))
+	omegaInvPowers := make([]fr.Element, k)
+	omegaInvPowers[0].SetOne()
+	for t := 1; t < len(omegaInvPowers); t++ {
+		omegaInvPowers[t].Mul(&omegaInvPowers[t-1], &omegaInv)
 	}
+	kInv.SetUint64(k).Inverse(&kInv)
+	return omegaInvPowers, kInv
+}
 
-	// check that the merkle roots coincide
-	if !bytes.Equal(openingProof.merkleRoot, pp.Rounds[0].Interactions[0][fullMerkleProof].MerkleRoot) {
-		return ErrMerkleRoot
+// foldCoset folds the evaluations vₜ = P(xωᵗ) of a polynomial P on a coset of
+// the k-th roots of unity. Writing P(X) = ∑ⱼ Xʲ Pⱼ(Xᵏ), it returns
+// ∑ⱼ αʲ Pⱼ(xᵏ), using xʲ Pⱼ(xᵏ) = k⁻¹ ∑ₜ ω⁻ᵗʲ vₜ.
+func foldCoset(v, omegaInvPowers []fr.Element, xInv, alpha, kInv fr.Element) fr.Element {
+	k := len(v)
+	var res, c, tmp, beta fr.Element
+	beta.Mul(&alpha, &xInv)
+	for j := k - 1; j >= 0; j-- {
+		c.SetZero()
+		for t := 0; t < k; t++ {
+			tmp.Mul(&v[t], &omegaInvPowers[(t*j)%k])
+			c.Add(&c, &tmp)
+		}
+		res.Mul(&res, &beta).Add(&res, &c)
 	}
+	res.Mul(&res, &kInv)
+	return res
+}
 
-	// convert position to the sorted version
-	sizePoly := s.domain.Cardinality
-	pos := convertCanonicalSorted(int(position), int(sizePoly))
-
-	// check the Merkle proof
-	res := merkletree.VerifyProof(s.h, openingProof.merkleRoot, openingProof.ProofSet, uint64(pos), openingProof.numLeaves)
-	if !res {
-		return ErrMerklePath
+// fold folds by a factor k the evaluations p, in natural order, of a polynomial
+// on the subgroup generated by g. It returns the evaluations of the folded
+// polynomial on the subgroup generated by gᵏ.
+func fold(p []fr.Element, g fr.Element, k uint64, alpha fr.Element) []fr.Element {
+	n := uint64(len(p)) / k
+	res := make([]fr.Element, n)
+
+	var gInv, xInv fr.Element
+	gInv.Inverse(&g)
+	xInv.SetOne()
+	omegaInvPowers, kInv := foldingConstants(gInv, uint64(len(p)), k)
+
+	coset := make([]fr.Element, k)
+	for l := uint64(0); l < n; l++ {
+		for t := uint64(0); t < k; t++ {
+			coset[t].Set(&p[l+t*n])
+		}
+		res[l] = foldCoset(coset, omegaInvPowers, xInv, alpha, kInv)
+		xInv.Mul(&xInv, &gInv)
 	}
-	return nil
-
+	return res
 }
 
-// foldPolynomialLagrangeBasis folds a polynomial p, expressed in Lagrange basis.
-//
-// Fᵣ[X]/(Xⁿ-1) is a free module of rank 2 on Fᵣ[Y]/(Y^{n/2}-1). If
-// p∈ Fᵣ[X]/(Xⁿ-1), expressed in Lagrange basis, the function finds the coordinates
-// p₁, p₂ of p in Fᵣ[Y]/(Y^{n/2}-1), expressed in Lagrange basis. Finally, it computes
-// p₁ + x*p₂ and returns it.
-//
-// * p is the polynomial to fold, in Lagrange basis, sorted like this: p = [p(1),p(-1),p(g),p(-g),p(g²),p(-g²),...]
-// * g is a generator of the subgroup of Fᵣ^{*} of size len(p)
-// * x is the folding challenge x, used to return p₁+x*p₂
-func foldPolynomialLagrangeBasis(pSorted []fr.Element, gInv, x fr.Element) []fr.Element {
-
-	// we have the following system
-	// p₁(g²ⁱ)+gⁱp₂(g²ⁱ) = p(gⁱ)
-	// p₁(g²ⁱ)-gⁱp₂(g²ⁱ) = p(-gⁱ)
-	// we solve the system for p₁(g²ⁱ),p₂(g²ⁱ)
-	s := len(pSorted)
-	res := make([]fr.Element, s/2)
-
-	var p1, p2, acc fr.Element
-	acc.SetOne()
-
-	for i := 0; i < s/2; i++ {
-
-		p1.Add(&pSorted[2*i], &pSorted[2*i+1])
-		p2.Sub(&pSorted[2*i], &pSorted[2*i+1]).Mul(&p2, &acc)
-		res[i].Mul(&p2, &x).Add(&res[i], &p1).Mul(&res[i], &twoInv)
-
-		acc.Mul(&acc, &gInv)
-
+// checkProofOfWork returns true if H(seed ‖ nonce) starts with s.grindingBits
+// zero bits.
+func (s radixTwoFri) checkProofOfWork(seed []byte, nonce uint64) bool {
+	if s.grindingBits == 0 {
+		return true
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], nonce)
+	s.h.Reset()
+	s.h.Write(seed)
+	s.h.Write(buf[:])
+	digest := s.h.Sum(nil)
+
+	nbZeros := 0
+	for _, b := range digest {
+		if b != 0 {
+			nbZeros += bits.LeadingZeros8(b)
+			break
+		}
+		nbZeros += 8
 	}
+	return nbZeros >= s.grindingBits
+}
 
+// deriveQueries derives the positions, in the first oracle, queried by the
+// verifier.
+func (s radixTwoFri) deriveQueries(seed []byte) []uint64 {
+	res := make([]uint64, s.nbQueries)
+	var bPos, bCardinality big.Int
+	bCardinality.SetUint64(s.domain.Cardinality)
+	var buf [4]byte
+	for i := range res {
+		binary.BigEndian.PutUint32(buf[:], uint32(i))
+		s.h.Reset()
+		s.h.Write(seed)
+		s.h.Write(buf[:])
+		bPos.SetBytes(s.h.Sum(nil))
+		bPos.Mod(&bPos, &bCardinality)
+		res[i] = bPos.Uint64()
+	}
 	return res
 }
 
-// buildProofOfProximitySingleRound generates a proof that a function, given as an oracle from
-// the verifier point of view, is in fact δ-close to a polynomial.
-// * salt is a variable for multi rounds, it allows to generate different challenges using Fiat Shamir
-// * p is in evaluation form
-func (s radixTwoFri) buildProofOfProximitySingleRound(salt fr.Element, p []fr.Element) (Round, error) {
-
-	// the proof will contain nbSteps Interactions
-	var res Round
-	res.Interactions = make([][2]MerkleProof, s.nbSteps)
-
-	// Fiat Shamir transcript to derive the challenges. The xᵢ are used to fold the
-	// polynomials.
-	// During the i-th round, the prover has a polynomial P of degree n. The verifier sends
-	// xᵢ∈ Fᵣ to the prover. The prover expresses F in Fᵣ[X,Y]/<Y-X²> as
-	// P₀(Y)+X P₁(Y) where P₀, P₁ are of degree n/2, and he then folds the polynomial
-	// by replacing x by xᵢ.
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
-	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
-
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
+// queryPhase binds the final polynomial, grinds and derives the queries.
+// If prove is false, the nonce of the proof is checked instead of computed.
+func (s radixTwoFri) queryPhase(fs *fiatshamir.Transcript, proof *ProofOfProximity, prove bool) ([]uint64, error) {
+	for i := range proof.FinalPolynomial {
+		if err := fs.Bind("grinding", proof.FinalPolynomial[i].Marshal()); err != nil {
+			return nil, err
+		}
+	}
+	seed, err := fs.ComputeChallenge("grinding")
 	if err != nil {
-		return Round{}, err
+		return nil, err
+	}
+	if prove {
+		for proof.Nonce = 0; !s.checkProofOfWork(seed, proof.Nonce); proof.Nonce++ {
+		}
+	} else if !s.checkProofOfWork(seed, proof.Nonce) {
+		return nil, ErrProofOfWork
 	}
 
-	// step 1 : fold the polynomial using the xi
-
-	// evalsAtRound stores the list of the nbSteps polynomial evaluations, each evaluation
-	// corresponds to the evaluation o the folded polynomial at round i.
-	evalsAtRound := make([][]fr.Element, s.nbSteps)
-
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
-
-	// gInv inverse of the generator of the cyclic group of size the size of the polynomial.
-	// The size of the cyclic group is ρ*s.domainSize, and not s.domainSize.
-	var gInv fr.Element
-	gInv.Set(&s.domain.GeneratorInv)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], proof.Nonce)
+	if err = fs.Bind("queries", buf[:]); err != nil {
+		return nil, err
+	}
+	seed, err = fs.ComputeChallenge("queries")
+	if err != nil {
+		return nil, err
+	}
+	return s.deriveQueries(seed), nil
+}
 
-	for i := 0; i < s.nbSteps; i++ {
+// prove builds a proof of proximity of the evaluations p, in natural order on
+// s.domain. It returns the proof and the queried positions.
+func (s radixTwoFri) prove(fs *fiatshamir.Transcript, p []fr.Element) (ProofOfProximity, []uint64, error) {
 
-		evalsAtRound[i] = sort(_p)
+	var proof ProofOfProximity
+	proof.Rounds = make([]Round, len(s.foldingFactors))
+	trees := make([]*merkletree.FullTree, len(s.foldingFactors))
 
-		// compute the root hash, needed to derive xi
-		t := merkletree.New(s.h)
-		for k := 0; k < len(_p); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
-		}
-		rh := t.Root()
-		err := fs.Bind(xis[i], rh)
+	// step 1: commit to the successive foldings of p. At step i, the verifier
+	// sends xᵢ, and the prover folds P(X) = ∑ⱼ Xʲ Pⱼ(Xᵏ) into ∑ⱼ xᵢʲ Pⱼ.
+	var err error
+	g := s.domain.Generator
+	for i, k := range s.foldingFactors {
+		trees[i], err = merkletree.NewFullTree(s.h, cosetLeaves(p, k))
 		if err != nil {
-			return res, err
+			return proof, nil, err
 		}
+		proof.Rounds[i].MerkleRoot = trees[i].Root()
 
-		// derive the challenge
-		bxi, err := fs.ComputeChallenge(xis[i])
+		name := fmt.Sprintf("x%d", i)
+		if err = fs.Bind(name, proof.Rounds[i].MerkleRoot); err != nil {
+			return proof, nil, err
+		}
+		bxi, err := fs.ComputeChallenge(name)
 		if err != nil {
-			return res, err
+			return proof, nil, err
 		}
 		var xi fr.Element
 		xi.SetBytes(bxi)
 
-		// fold _p, reusing its memory
-		_p = foldPolynomialLagrangeBasis(evalsAtRound[i], gInv, xi)
-
-		// g <- g²
-		gInv.Square(&gInv)
-
+		p = fold(p, g, k, xi)
+		g.Exp(g, new(big.Int).SetUint64(k))
 	}
 
-	// last round, provide the evaluation. The fully folded polynomial is of size rho. It should
-	// correspond to the evaluation of a polynomial of degree 1 on ρ points, so those points
-	// are supposed to be on a line.
-	res.Evaluation.Set(&_p[0])
+	// the fully folded polynomial is sent in canonical basis
+	d := fft.NewDomain(uint64(len(p)))
+	d.FFTInverse(p, fft.DIF)
+	fft.BitReverse(p)
+	proof.FinalPolynomial = p[:s.finalSize]
 
 	// step 2: provide the Merkle proofs of the queries
-
-	// derive the verifier queries
-	err = fs.Bind(xis[s.nbSteps], res.Evaluation.Marshal())
-	if err != nil {
-		return res, err
-	}
-	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
+	queries, err := s.queryPhase(fs, &proof, true)
 	if err != nil {
-		return res, err
+		return proof, nil, err
 	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
-
-	for i := 0; i < s.nbSteps; i++ {
-
-		// build proofs of queries at s[i]
-		t := merkletree.New(s.h)
-		err := t.SetIndex(uint64(si[i]))
-		if err != nil {
-			return res, err
-		}
-		for k := 0; k < len(evalsAtRound[i]); k++ {
-			t.Push(evalsAtRound[i][k].Marshal())
+	positions := slices.Clone(queries)
+	for i := range proof.Rounds {
+		nbLeaves := trees[i].NumLeaves()
+		for j := range positions {
+			positions[j] %= nbLeaves
 		}
-		mr, ProofSet, _, numLeaves := t.Prove()
-
-		// c denotes the entry that contains the full Merkle proof. The entry 1-c will
-		// only contain 2 elements, which are the neighbor point, and the hash of the
-		// first point. The remaining of the Merkle path is common to both the original
-		// point and its neighbor.
-		c := si[i] % 2
-		res.Interactions[i][c] = MerkleProof{mr, ProofSet, numLeaves}
-		res.Interactions[i][1-c] = MerkleProof{
-			mr,
-			make([][]byte, 2),
-			numLeaves,
-		}
-		res.Interactions[i][1-c].ProofSet[0] = evalsAtRound[i][si[i]+1-2*c].Marshal()
-		s.h.Reset()
-		_, err = s.h.Write(res.Interactions[i][c].ProofSet[0])
-		if err != nil {
-			return res, err
+		if proof.Rounds[i].Proof, err = trees[i].ProveMulti(positions); err != nil {
+			return proof, nil, err
 		}
-		res.Interactions[i][1-c].ProofSet[1] = s.h.Sum(nil)
-
 	}
 
-	return res, nil
-
+	return proof, queries, nil
 }
 
-// BuildProofOfProximity generates a proof that a function, given as an oracle from
-// the verifier point of view, is in fact δ-close to a polynomial.
-func (s radixTwoFri) BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error) {
-
-	// the proof will contain nbSteps Interactions
-	var proof ProofOfProximity
-	proof.Rounds = make([]Round, nbRounds)
-
-	// evaluate p
-	// evaluate p and sort the result
-	_p := make([]fr.Element, s.domain.Cardinality)
-	copy(_p, p)
-	s.domain.FFT(_p, fft.DIF)
-	fft.BitReverse(_p)
-
-	var err error
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		proof.Rounds[i], err = s.buildProofOfProximitySingleRound(salt, _p)
+// openedCosets checks the multiproof against the leaves containing the
+// positions, and returns the opened leaves of k entries indexed by leaf.
+func (s radixTwoFri) openedCosets(root []byte, proof merkletree.MultiProof, positions []uint64, nbLeaves, k uint64) (map[uint64][]fr.Element, error) {
+	indices := make([]uint64, len(positions))
+	for j := range positions {
+		indices[j] = positions[j] % nbLeaves
+	}
+	slices.Sort(indices)
+	indices = slices.Compact(indices)
+	if proof.NumLeaves != nbLeaves || !slices.Equal(indices, proof.Indices) {
+		return nil, ErrMerklePath
+	}
+	if !merkletree.VerifyMultiProof(s.h, root, proof) {
+		return nil, ErrMerklePath
+	}
+	res := make(map[uint64][]fr.Element, len(indices))
+	for i, index := range indices {
+		coset, err := decodeCoset(proof.Leaves[i], k)
 		if err != nil {
-			return proof, err
+			return nil, err
 		}
-		salt.Add(&salt, &one)
+		res[index] = coset
 	}
-
-	return proof, nil
+	return res, nil
 }
 
-// verifyProofOfProximitySingleRound verifies the proof of proximity. It returns an error if the
-// verification fails.
-func (s radixTwoFri) verifyProofOfProximitySingleRound(salt fr.Element, proof Round) error {
-
-	// Fiat Shamir transcript to derive the challenges
-	xis := make([]string, s.nbSteps+1)
-	for i := 0; i < s.nbSteps; i++ {
-		xis[i] = fmt.Sprintf("x%d", i)
-	}
-	xis[s.nbSteps] = "s0"
-	fs := fiatshamir.NewTranscript(s.h, xis...)
-
-	xi := make([]fr.Element, s.nbSteps)
+// verify verifies a proof of proximity. It returns the queried positions and
+// the cosets opened in the first oracle.
+func (s radixTwoFri) verify(fs *fiatshamir.Transcript, proof ProofOfProximity) ([]uint64, map[uint64][]fr.Element, error) {
 
-	// the salt is binded to the first challenge, to ensure the challenges
-	// are different at each round.
-	err := fs.Bind(xis[0], salt.Marshal())
-	if err != nil {
-		return err
+	if len(proof.Rounds) != len(s.foldingFactors) || uint64(len(proof.FinalPolynomial)) != s.finalSize {
+		return nil, nil, ErrMalformedProof
 	}
 
-	for i := 0; i < s.nbSteps; i++ {
-		err := fs.Bind(xis[i], proof.Interactions[i][0].MerkleRoot)
-		if err != nil {
-			return err
+	xis := make([]fr.Element, len(s.foldingFactors))
+	for i := range proof.Rounds {
+		name := fmt.Sprintf("x%d", i)
+		if err := fs.Bind(name, proof.Rounds[i].MerkleRoot); err != nil {
+			return nil, nil, err
 		}
-		bxi, err := fs.ComputeChallenge(xis[i])
+		bxi, err := fs.ComputeChallenge(name)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		xi[i].SetBytes(bxi)
+		xis[i].SetBytes(bxi)
 	}
 
-	// derive the verifier queries
-	// for i := 0; i < len(proof.evaluation); i++ {
-	// 	err := fs.Bind(xis[s.nbSteps], proof.evaluation[i].Marshal())
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-	err = fs.Bind(xis[s.nbSteps], proof.Evaluation.Marshal())
+	queries, err := s.queryPhase(fs, &proof, false)
 	if err != nil {
-		return err
-	}
-	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
-	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	var bPos, bCardinality big.Int
-	bPos.SetBytes(binSeed)
-	bCardinality.SetUint64(s.domain.Cardinality)
-	bPos.Mod(&bPos, &bCardinality)
-	si := s.deriveQueriesPositions(int(bPos.Uint64()), int(s.domain.Cardinality))
-
-	// for each round check the Merkle proof and the correctness of the folding
-
-	// current size of the polynomial
-	var accGInv fr.Element
-	accGInv.Set(&s.domain.GeneratorInv)
-	for i := 0; i < s.nbSteps; i++ {
-
-		// correctness of Merkle proof
-		// c is the entry containing the full Merkle proof.
-		c := si[i] % 2
-		res := merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][c].MerkleRoot,
-			proof.Interactions[i][c].ProofSet,
-			uint64(si[i]),
-			proof.Interactions[i][c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
-		}
 
-		// we verify the Merkle proof for the neighbor query, to do that we have
-		// to pick the full Merkle proof of the first entry, stripped off of the leaf and
-		// the first node. We replace the leaf and the first node by the leaf and the first
-		// node of the partial Merkle proof, since the leaf and the first node of both proofs
-		// are the only entries that differ.
-		ProofSet := make([][]byte, len(proof.Interactions[i][c].ProofSet))
-		copy(ProofSet[2:], proof.Interactions[i][c].ProofSet[2:])
-		ProofSet[0] = proof.Interactions[i][1-c].ProofSet[0]
-		ProofSet[1] = proof.Interactions[i][1-c].ProofSet[1]
-		res = merkletree.VerifyProof(
-			s.h,
-			proof.Interactions[i][1-c].MerkleRoot,
-			ProofSet,
-			uint64(si[i]+1-2*c),
-			proof.Interactions[i][1-c].numLeaves,
-		)
-		if !res {
-			return ErrMerklePath
+	// for each step, check the Merkle proofs and the correctness of the folding.
+	// values[j] is the value at positions[j] of the oracle of the current step,
+	// as computed from the previous step.
+	var firstCosets map[uint64][]fr.Element
+	positions := slices.Clone(queries)
+	values := make([]fr.Element, len(queries))
+	g := s.domain.Generator
+	n := s.domain.Cardinality
+	for i, k := range s.foldingFactors {
+		var gInv fr.Element
+		gInv.Inverse(&g)
+		omegaInvPowers, kInv := foldingConstants(gInv, n, k)
+		n /= k
+
+		cosets, err := s.openedCosets(proof.Rounds[i].MerkleRoot, proof.Rounds[i].Proof, positions, n, k)
+		if err != nil {
+			return nil, nil, err
+		}
+		if i == 0 {
+			firstCosets = cosets
 		}
 
-		// correctness of the folding
-		if i < s.nbSteps-1 {
+		for j := range positions {
+			l, t := positions[j]%n, positions[j]/n
+			coset := cosets[l]
+			if i > 0 && !coset[t].Equal(&values[j]) {
+				return nil, nil, ErrProximityTestFolding
+			}
+			var xInv fr.Element
+			xInv.Exp(gInv, new(big.Int).SetUint64(l))
+			values[j] = foldCoset(coset, omegaInvPowers, xInv, xis[i], kInv)
+			positions[j] = l
+		}
 
-			var fe, fo, l, r, fn fr.Element
+		g.Exp(g, new(big.Int).SetUint64(k))
+	}
 
-			// l = P(gⁱ), r = P(g^{i+n/2})
-			l.SetBytes(proof.Interactions[i][0].ProofSet[0])
-			r.SetBytes(proof.Interactions[i][1].ProofSet[0])
+	// the fully folded values should be evaluations of the final polynomial
+	for j := range positions {
+		var x, e fr.Element
+		x.Exp(g, new(big.Int).SetUint64(positions[j]))
+		for i := len(proof.FinalPolynomial) - 1; i >= 0; i-- {
+			e.Mul(&e, &x).Add(&e, &proof.FinalPolynomial[i])
+		}
+		if !e.Equal(&values[j]) {
+			return nil, nil, ErrLowDegree
+		}
+	}
 
-			// (g^{si[i]}, g^{si[i]+1}) is the fiber of g^{2*si[i]}. The system to solve
-			// (for P₀(g^{2si[i]}), P₀(g^{2si[i]}) ) is:
-			// P(g^{si[i]}) = P₀(g^{2si[i]}) +  g^{si[i]/2}*P₀(g^{2si[i]})
-			// P(g^{si[i]+1}) = P₀(g^{2si[i]}) -  g^{si[i]/2}*P₀(g^{2si[i]})
-			bm := big.NewInt(int64(si[i] / 2))
-			var ginv fr.Element
-			ginv.Exp(accGInv, bm)
-			fe.Add(&l, &r)                                      // P₁(g²ⁱ) (to be multiplied by 2⁻¹)
-			fo.Sub(&l, &r).Mul(&fo, &ginv)                      // P₀(g²ⁱ) (to be multiplied by 2⁻¹)
-			fo.Mul(&fo, &xi[i]).Add(&fo, &fe).Mul(&fo, &twoInv) // P₀(g²ⁱ) + xᵢ * P₁(g²ⁱ)
+	return queries, firstCosets, nil
+}
 
-			fn.SetBytes(proof.Interactions[i+1][si[i+1]%2].ProofSet[0])
+// BuildProofOfProximity generates a proof that a function, given as an oracle from
+// the verifier point of view, is in fact δ-close to a polynomial.
+func (s radixTwoFri) BuildProofOfProximity(p []fr.Element) (ProofOfProximity, error) {
+	if uint64(len(p)) > s.size {
+		return ProofOfProximity{}, ErrPolynomialSize
+	}
+	fs := fiatshamir.NewTranscript(s.h, s.challengeNames(false)...)
+	proof, _, err := s.prove(fs, s.evaluate(p))
+	return proof, err
+}
 
-			if !fo.Equal(&fn) {
-				return ErrProximityTestFolding
-			}
+// VerifyProofOfProximity verifies the proof, by checking the consistency of
+// the foldings at each query.
+func (s radixTwoFri) VerifyProofOfProximity(proof ProofOfProximity) error {
+	fs := fiatshamir.NewTranscript(s.h, s.challengeNames(false)...)
+	_, _, err := s.verify(fs, proof)
+	return err
+}
 
-			// next inverse generator
-			accGInv.Square(&accGInv)
-		}
+// Opens a polynomial at gⁱ where i = position.
+func (s radixTwoFri) Open(p []fr.Element, position uint64) (OpeningProof, error) {
 
+	// check that position is in the correct range
+	if position >= s.domain.Cardinality {
+		return OpeningProof{}, ErrRangePosition
 	}
 
-	// last transition
-	var fe, fo, l, r fr.Element
-
-	l.SetBytes(proof.Interactions[s.nbSteps-1][0].ProofSet[0])
-	r.SetBytes(proof.Interactions[s.nbSteps-1][1].ProofSet[0])
+	// the opened entry belongs to the leaf position mod n of the first oracle
+	k := s.foldingFactors[0]
+	n := s.domain.Cardinality / k
+	tree := merkletree.New(s.h)
+	if err := tree.SetIndex(position % n); err != nil {
+		return OpeningProof{}, err
+	}
+	for _, leaf := range cosetLeaves(s.evaluate(p), k) {
+		tree.Push(leaf)
+	}
+	var res OpeningProof
+	res.MerkleRoot, res.ProofSet, res.Index, res.NumLeaves = tree.Prove()
 
-	_si := si[s.nbSteps-1] / 2
+	// set the claimed value, which is an entry of the first element of the Merkle proof
+	t := position / n
+	res.ClaimedValue.SetBytes(res.ProofSet[0][t*fr.Bytes : (t+1)*fr.Bytes])
 
-	accGInv.Exp(accGInv, big.NewInt(int64(_si)))
+	return res, nil
+}
 
-	fe.Add(&l, &r)                                                // P₁(g²ⁱ) (to be multiplied by 2⁻¹)
-	fo.Sub(&l, &r).Mul(&fo, &accGInv)                             // P₀(g²ⁱ) (to be multiplied by 2⁻¹)
-	fo.Mul(&fo, &xi[s.nbSteps-1]).Add(&fo, &fe).Mul(&fo, &twoInv) // P₀(g²ⁱ) + xᵢ * P₁(g²ⁱ)
+// Verifies the opening of a polynomial.
+// * position the point at which the proof is opened (the point is gⁱ where i = position)
+// * openingProof Merkle path proof
+// * pp proof of proximity, needed because before opening Merkle path proof one should be sure that the
+// committed values come from a polynomial. During the verification of the Merkle path proof, the root
+// hash of the Merkle path is compared to the root hash of the first round of the proof of proximity,
+// those should be equal, if not an error is raised.
+func (s radixTwoFri) VerifyOpening(position uint64, openingProof OpeningProof, pp ProofOfProximity) error {
 
-	// Last step: the final evaluation should be the evaluation of a degree 0 polynomial,
-	// so it must be constant.
-	if !fo.Equal(&proof.Evaluation) {
-		return ErrProximityTestFolding
+	if position >= s.domain.Cardinality {
+		return ErrRangePosition
 	}
 
-	return nil
-}
+	// check that the merkle roots coincide
+	if len(pp.Rounds) == 0 || !bytes.Equal(openingProof.MerkleRoot, pp.Rounds[0].MerkleRoot) {
+		return ErrMerkleRoot
+	}
 
-// VerifyProofOfProximity verifies the proof, by checking each interaction one
-// by one.
-func (s radixTwoFri) VerifyProofOfProximity(proof ProofOfProximity) error {
+	// check the Merkle proof of the leaf containing the position
+	k := s.foldingFactors[0]
+	n := s.domain.Cardinality / k
+	if openingProof.NumLeaves != n || len(openingProof.ProofSet) == 0 {
+		return ErrMerklePath
+	}
+	if !merkletree.VerifyProof(s.h, openingProof.MerkleRoot, openingProof.ProofSet, position%n, n) {
+		return ErrMerklePath
+	}
 
-	var salt, one fr.Element
-	one.SetOne()
-	for i := 0; i < nbRounds; i++ {
-		err := s.verifyProofOfProximitySingleRound(salt, proof.Rounds[i])
-		if err != nil {
-			return err
-		}
-		salt.Add(&salt, &one)
+	// check the claimed value against the leaf
+	coset, err := decodeCoset(openingProof.ProofSet[0], k)
+	if err != nil {
+		return err
+	}
+	if !coset[position/n].Equal(&openingProof.ClaimedValue) {
+		return ErrClaimedValue
 	}
 	return nil
-
 }
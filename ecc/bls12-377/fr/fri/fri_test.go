@@ -6,30 +6,21 @@
 package fri
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils/testutils"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
 )
 
-// logFiber returns u, v such that {g^u, g^v} = f⁻¹((g²)^{_p})
-func logFiber(_p, _n int) (_u, _v big.Int) {
-	if _p%2 == 0 {
-		_u.SetInt64(int64(_p / 2))
-		_v.SetInt64(int64(_p/2 + _n/2))
-	} else {
-		l := (_n - 1 - _p) / 2
-		_u.SetInt64(int64(_n - 1 - l))
-		_v.SetInt64(int64(_n - 1 - l - _n/2))
-	}
-	return
-}
-
 func randomPolynomial(size uint64, seed int32) []fr.Element {
 	p := make([]fr.Element, size)
 	p[0].SetUint64(uint64(seed))
@@ -39,18 +30,6 @@ func randomPolynomial(size uint64, seed int32) []fr.Element {
 	return p
 }
 
-// convertSortedCanonical convert the index i, an entry in a
-// sorted polynomial, to the corresponding entry in canonical
-// representation. n is the size of the polynomial.
-func convertSortedCanonical(i, n int) int {
-	if i%2 == 0 {
-		return i / 2
-	} else {
-		l := (n - 1 - i) / 2
-		return n - 1 - l
-	}
-}
-
 func TestFRI(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -144,41 +123,6 @@ func TestFRI(t *testing.T) {
 		gen.Int32Range(0, int32(rho*size)),
 	))
 
-	properties.Property("Derive queries position: points should belong the correct fiber", prop.ForAll(
-
-		func(m int32) bool {
-
-			_s := RADIX_2_FRI.New(uint64(size), sha256.New())
-			s := _s.(radixTwoFri)
-
-			var g fr.Element
-
-			_m := int(m) % size
-			pos := s.deriveQueriesPositions(_m, int(s.domain.Cardinality))
-			g.Set(&s.domain.Generator)
-			n := int(s.domain.Cardinality)
-
-			for i := 0; i < len(pos)-1; i++ {
-
-				u, v := logFiber(pos[i], n)
-
-				var g1, g2, g3 fr.Element
-				g1.Exp(g, &u).Square(&g1)
-				g2.Exp(g, &v).Square(&g2)
-				nextPos := convertSortedCanonical(pos[i+1], n/2)
-				g3.Square(&g).Exp(g3, big.NewInt(int64(nextPos)))
-
-				if !g1.Equal(&g2) || !g1.Equal(&g3) {
-					return false
-				}
-				g.Square(&g)
-				n = n >> 1
-			}
-			return true
-		},
-		gen.Int32Range(0, int32(rho*size)),
-	))
-
 	properties.Property("verifying a correctly formed proof should succeed", prop.ForAll(
 
 		func(s int32) bool {
@@ -201,8 +145,176 @@ func TestFRI(t *testing.T) {
 
 }
 
+func TestFRIOptions(t *testing.T) {
+
+	size := 1000
+	p := randomPolynomial(uint64(size), 7)
+
+	for _, options := range [][]Option{
+		{WithFoldingFactor(4)},
+		{WithFoldingFactor(8), WithNbQueries(20)},
+		{WithFoldingFactor(16), WithBlowupFactor(2)},
+		{WithFoldingFactor(4), WithFinalDegree(5)},
+		{WithFinalDegree(2000)},
+		{WithBlowupFactor(4), WithGrinding(8), WithNbQueries(NbQueries(40, 4, 8))},
+	} {
+		iop := RADIX_2_FRI.New(uint64(size), sha256.New(), options...)
+		proof, err := iop.BuildProofOfProximity(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = iop.VerifyProofOfProximity(proof); err != nil {
+			t.Fatal(err)
+		}
+
+		// the opening and the proof of proximity share the first oracle
+		openingProof, err := iop.Open(p, 42)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = iop.VerifyOpening(42, openingProof, proof); err != nil {
+			t.Fatal(err)
+		}
+
+		// the verifier should use the same options
+		if err = RADIX_2_FRI.New(uint64(size), sha256.New()).VerifyProofOfProximity(proof); err == nil {
+			t.Fatal("verification with different options should fail")
+		}
+
+		// tampered proofs
+		proof.Nonce++
+		if err = iop.VerifyProofOfProximity(proof); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+		proof.Nonce--
+		proof.FinalPolynomial[0].SetRandom()
+		if err = iop.VerifyProofOfProximity(proof); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+	}
+
+	// a polynomial of higher degree is rejected
+	q := randomPolynomial(uint64(size), 11)
+	q = append(q, q...)
+	iop := RADIX_2_FRI.New(uint64(size), sha256.New(), WithNbQueries(10))
+	if _, err := iop.BuildProofOfProximity(q); err != ErrPolynomialSize {
+		t.Fatal("expected ErrPolynomialSize")
+	}
+
+	// bypass the size check of the prover
+	s := iop.(radixTwoFri)
+	fs := fiatshamir.NewTranscript(s.h, s.challengeNames(false)...)
+	proof, _, err := s.prove(fs, s.evaluate(q))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = iop.VerifyProofOfProximity(proof); err == nil {
+		t.Fatal("verification of a polynomial of higher degree should fail")
+	}
+}
+
+func TestNbQueries(t *testing.T) {
+	if n := NbQueries(128, 8, 0); n != 43 {
+		t.Fatalf("expected 43 queries, got %d", n)
+	}
+	if n := NbQueries(100, 16, 20); n != 20 {
+		t.Fatalf("expected 20 queries, got %d", n)
+	}
+}
+
+func TestBatchFRI(t *testing.T) {
+
+	sizes := []int{1024, 1000, 300, 1}
+	ps := make([][]fr.Element, len(sizes))
+	for i := range ps {
+		ps[i] = randomPolynomial(uint64(sizes[i]), int32(i+2))
+	}
+
+	iop := RADIX_2_FRI.New(1024, sha256.New(), WithFoldingFactor(4), WithNbQueries(16))
+	proof, err := iop.BuildBatchProofOfProximity(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = iop.VerifyBatchProofOfProximity(sizes, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// an empty batch is rejected
+	if _, err = iop.BuildBatchProofOfProximity(nil); err != ErrEmptyBatch {
+		t.Fatal("building a proof of an empty batch should fail")
+	}
+
+	// the sizes are part of the statement
+	if err = iop.VerifyBatchProofOfProximity([]int{1024, 1000, 200, 1}, proof); err == nil {
+		t.Fatal("verification with wrong sizes should fail")
+	}
+
+	// a polynomial larger than its claimed size is detected
+	ps[2] = randomPolynomial(400, 9)
+	proof, err = iop.BuildBatchProofOfProximity(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes[2] = 400
+	if err = iop.VerifyBatchProofOfProximity(sizes, proof); err != nil {
+		t.Fatal(err)
+	}
+	proof.Proof.Leaves[0][5] ^= 1
+	if err = iop.VerifyBatchProofOfProximity(sizes, proof); err == nil {
+		t.Fatal("verification of a tampered proof should fail")
+	}
+}
+
+func TestBatchFRIDegree(t *testing.T) {
+
+	// p is of size 512, claimed to be of size 256
+	p := randomPolynomial(512, 5)
+	iop := RADIX_2_FRI.New(1024, sha256.New(), WithNbQueries(16))
+	proof, err := iop.BuildBatchProofOfProximity([][]fr.Element{p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = iop.VerifyBatchProofOfProximity([]int{256}, proof); err == nil {
+		t.Fatal("verification with a smaller size should fail")
+	}
+}
+
 // Benchmarks
 
+func TestSerialization(t *testing.T) {
+
+	size := 1024
+	s := RADIX_2_FRI.New(uint64(size), sha256.New())
+	p := randomPolynomial(uint64(size), 13)
+
+	pp, err := s.BuildProofOfProximity(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	openingProof, err := s.Open(p, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := RADIX_2_FRI.New(uint64(size), sha256.New(), WithFinalDegree(3)).
+		BuildBatchProofOfProximity([][]fr.Element{p, p[:100]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("proof of proximity round trip", testutils.SerializationRoundTrip(&pp))
+	t.Run("opening proof round trip", testutils.SerializationRoundTrip(&openingProof))
+	t.Run("batched proof of proximity round trip", testutils.SerializationRoundTrip(&batch))
+
+	// length prefixes larger than the input aren't allocated upfront
+	if _, err = pp.ReadFrom(bytes.NewReader([]byte{serializationVersion, 0xff, 0xff, 0xff, 0xff})); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if _, err = openingProof.ReadFrom(bytes.NewReader([]byte{serializationVersion, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
 func BenchmarkProximityVerification(b *testing.B) {
 
 	baseSize := 16
@@ -0,0 +1,26 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package logup provides an API to build LogUp lookup proofs.
+//
+// LogUp (https://eprint.iacr.org/2022/1530.pdf) proves that the rows of
+// witness tables f₀, ..., f_{K-1} are rows of a table t, using the
+// logarithmic derivative identity
+//
+//	∑ₖ ∑ᵢ 1/(β - fₖ[i]) = ∑ᵢ m[i]/(β - t[i])
+//
+// where m[i] is the number of times t[i] is looked up. Multi-column tables are
+// compressed to a single column with a random challenge λ, as ∑ⱼ λʲ⋅colⱼ.
+//
+// Two versions of the argument are provided:
+//   - a univariate one (ProveLookup, VerifyLookup), where the columns are
+//     committed with KZG and the identity is proven with a running sum;
+//   - a multilinear one (ProveLookupMultilinear, VerifyLookupMultilinear),
+//     where the identity is proven with a tree of fractional sums reduced
+//     layer by layer with sumcheck (https://eprint.iacr.org/2023/1284.pdf).
+//     It reduces the lookup to evaluation claims on the multilinear
+//     extensions of the columns, which the caller checks against its own
+//     commitments.
+package logup
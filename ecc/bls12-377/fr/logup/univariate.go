@@ -0,0 +1,529 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ProofLookup is a univariate LogUp proof that the rows of the witness tables
+// are rows of the table.
+type ProofLookup struct {
+
+	// Size of the evaluation domain
+	Size uint64
+
+	// Commitments to the columns of the table and of the witness tables
+	Table     []kzg.Digest
+	Witnesses [][]kzg.Digest
+
+	// Commitment to the multiplicities of the rows of the table
+	Multiplicities kzg.Digest
+
+	// Commitments to the inverses hₖ = 1/(β-fₖ) and hₜ = m/(β-t)
+	Inverses     []kzg.Digest
+	TableInverse kzg.Digest
+
+	// Commitment to the running sum Z(ωx) = Z(x) + ∑ₖhₖ(x) - hₜ(x)
+	Z kzg.Digest
+
+	// Commitment to the quotient
+	Quotient kzg.Digest
+
+	// Batch opening proof of the table, the witnesses, m, the hₖ, hₜ, Z and
+	// the quotient at ζ
+	BatchedProof kzg.BatchOpeningProof
+
+	// Opening proof of Z at ωζ
+	ZShiftedProof kzg.OpeningProof
+}
+
+// ProveLookup returns a proof that each row of the witness tables fs is a row
+// of the table t. The tables are given column by column. They are padded to
+// the next power of two, the table by repeating its last row and the
+// witnesses with the first row of the table.
+//
+// /!\IMPORTANT/!\
+//
+// The commitments to the columns are in proof.Table and proof.Witnesses. If
+// the table or the witnesses are already committed somewhere, it is up to the
+// caller to check that they match.
+func ProveLookup(pk kzg.ProvingKey, t []fr.Vector, fs ...[]fr.Vector) (ProofLookup, error) {
+
+	var proof ProofLookup
+	var err error
+
+	if len(fs) == 0 {
+		return proof, ErrNoWitness
+	}
+	nbColumns := len(t)
+	size, err := nbRowsOf(t, nbColumns)
+	if err != nil {
+		return proof, err
+	}
+	for k := range fs {
+		n, err := nbRowsOf(fs[k], nbColumns)
+		if err != nil {
+			return proof, err
+		}
+		size = max(size, n)
+	}
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	transcript := fiatshamir.NewTranscript(hFunc, "lambda", "beta", "alpha", "zeta")
+
+	domainSmall := fft.NewDomain(uint64(size))
+	n := int(domainSmall.Cardinality)
+	proof.Size = domainSmall.Cardinality
+
+	// pad the tables
+	lt := make([]fr.Vector, nbColumns)
+	lfs := make([][]fr.Vector, len(fs))
+	for j := range t {
+		lt[j] = pad(t[j], t[j][len(t[j])-1], n)
+	}
+	for k := range fs {
+		lfs[k] = make([]fr.Vector, nbColumns)
+		for j := range fs[k] {
+			lfs[k][j] = pad(fs[k][j], t[j][0], n)
+		}
+	}
+
+	lm, err := Multiplicities(lt, lfs...)
+	if err != nil {
+		return proof, err
+	}
+
+	// commit to the columns and the multiplicities
+	ct := make([][]fr.Element, nbColumns)
+	proof.Table = make([]kzg.Digest, nbColumns)
+	for j := range lt {
+		if ct[j], proof.Table[j], err = commitLagrange(lt[j], domainSmall, pk); err != nil {
+			return proof, err
+		}
+	}
+	cfs := make([][][]fr.Element, len(fs))
+	proof.Witnesses = make([][]kzg.Digest, len(fs))
+	for k := range lfs {
+		cfs[k] = make([][]fr.Element, nbColumns)
+		proof.Witnesses[k] = make([]kzg.Digest, nbColumns)
+		for j := range lfs[k] {
+			if cfs[k][j], proof.Witnesses[k][j], err = commitLagrange(lfs[k][j], domainSmall, pk); err != nil {
+				return proof, err
+			}
+		}
+	}
+	cm, dm, err := commitLagrange(lm, domainSmall, pk)
+	if err != nil {
+		return proof, err
+	}
+	proof.Multiplicities = dm
+
+	// derive lambda, beta
+	if err = bindSize(transcript, proof.Size); err != nil {
+		return proof, err
+	}
+	lambda, err := deriveRandomness(transcript, "lambda", proof.columnDigests()...)
+	if err != nil {
+		return proof, err
+	}
+	beta, err := deriveRandomness(transcript, "beta")
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the inverses hₖ = 1/(β-fₖ) and hₜ = m/(β-t)
+	lhs := make([]fr.Vector, len(fs))
+	for k := range lfs {
+		lhs[k] = inverseOfShifted(compress(lfs[k], lambda), beta)
+	}
+	lht := inverseOfShifted(compress(lt, lambda), beta)
+	for i := range lht {
+		lht[i].Mul(&lht[i], &lm[i])
+	}
+
+	// running sum Z(ωⁱ⁺¹) = Z(ωⁱ) + ∑ₖhₖ(ωⁱ) - hₜ(ωⁱ), Z(1) = 0
+	lz := make(fr.Vector, n)
+	for i := 0; i < n-1; i++ {
+		lz[i+1].Sub(&lz[i], &lht[i])
+		for k := range lhs {
+			lz[i+1].Add(&lz[i+1], &lhs[k][i])
+		}
+	}
+
+	// commit to the hₖ, hₜ, Z
+	chs := make([][]fr.Element, len(fs))
+	proof.Inverses = make([]kzg.Digest, len(fs))
+	for k := range lhs {
+		if chs[k], proof.Inverses[k], err = commitLagrange(lhs[k], domainSmall, pk); err != nil {
+			return proof, err
+		}
+	}
+	cht, dht, err := commitLagrange(lht, domainSmall, pk)
+	if err != nil {
+		return proof, err
+	}
+	proof.TableInverse = dht
+	cz, dz, err := commitLagrange(lz, domainSmall, pk)
+	if err != nil {
+		return proof, err
+	}
+	proof.Z = dz
+
+	// derive alpha
+	toBind := make([]*bls12377.G1Affine, 0, len(fs)+2)
+	for k := range proof.Inverses {
+		toBind = append(toBind, &proof.Inverses[k])
+	}
+	toBind = append(toBind, &proof.TableInverse, &proof.Z)
+	alpha, err := deriveRandomness(transcript, "alpha", toBind...)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the quotient, on a coset of the domain of size 2n
+	domainBig := fft.NewDomain(uint64(2 * n))
+	_lt := make([][]fr.Element, nbColumns)
+	for j := range ct {
+		_lt[j] = evaluateOnCoset(ct[j], domainBig)
+	}
+	_lfs := make([][][]fr.Element, len(fs))
+	_lhs := make([][]fr.Element, len(fs))
+	for k := range cfs {
+		_lfs[k] = make([][]fr.Element, nbColumns)
+		for j := range cfs[k] {
+			_lfs[k][j] = evaluateOnCoset(cfs[k][j], domainBig)
+		}
+		_lhs[k] = evaluateOnCoset(chs[k], domainBig)
+	}
+	_lm := evaluateOnCoset(cm, domainBig)
+	_lht := evaluateOnCoset(cht, domainBig)
+	_lz := evaluateOnCoset(cz, domainBig)
+
+	cq := computeQuotientCanonical(alpha, beta, lambda, _lt, _lfs, _lm, _lhs, _lht, _lz, domainBig)
+	proof.Quotient, err = kzg.Commit(cq[:n], pk)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive zeta and build the opening proofs
+	zeta, err := deriveRandomness(transcript, "zeta", &proof.Quotient)
+	if err != nil {
+		return proof, err
+	}
+
+	polynomials := make([][]fr.Element, 0, nbColumns*(len(fs)+1)+len(fs)+4)
+	polynomials = append(polynomials, ct...)
+	for k := range cfs {
+		polynomials = append(polynomials, cfs[k]...)
+	}
+	polynomials = append(polynomials, cm)
+	polynomials = append(polynomials, chs...)
+	polynomials = append(polynomials, cht, cz, cq[:n])
+	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(polynomials, proof.openedDigests(), zeta, hFunc, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	zeta.Mul(&zeta, &domainSmall.Generator)
+	proof.ZShiftedProof, err = kzg.Open(cz, zeta, pk)
+	if err != nil {
+		return proof, err
+	}
+
+	return proof, nil
+}
+
+// VerifyLookup verifies that a ProofLookup proof is correct
+func VerifyLookup(vk kzg.VerifyingKey, proof ProofLookup) error {
+
+	// check the shape of the proof
+	nbColumns := len(proof.Table)
+	nbWitnesses := len(proof.Witnesses)
+	if nbColumns == 0 || nbWitnesses == 0 || len(proof.Inverses) != nbWitnesses {
+		return ErrMalformedProof
+	}
+	for k := range proof.Witnesses {
+		if len(proof.Witnesses[k]) != nbColumns {
+			return ErrMalformedProof
+		}
+	}
+	if proof.Size == 0 || bits.OnesCount64(proof.Size) != 1 {
+		return ErrMalformedProof
+	}
+	digests := proof.openedDigests()
+	if len(proof.BatchedProof.ClaimedValues) != len(digests) {
+		return ErrMalformedProof
+	}
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenges
+	transcript := fiatshamir.NewTranscript(hFunc, "lambda", "beta", "alpha", "zeta")
+
+	// derive the various challenges
+	if err := bindSize(transcript, proof.Size); err != nil {
+		return err
+	}
+	lambda, err := deriveRandomness(transcript, "lambda", proof.columnDigests()...)
+	if err != nil {
+		return err
+	}
+	beta, err := deriveRandomness(transcript, "beta")
+	if err != nil {
+		return err
+	}
+	toBind := make([]*bls12377.G1Affine, 0, nbWitnesses+2)
+	for k := range proof.Inverses {
+		toBind = append(toBind, &proof.Inverses[k])
+	}
+	toBind = append(toBind, &proof.TableInverse, &proof.Z)
+	alpha, err := deriveRandomness(transcript, "alpha", toBind...)
+	if err != nil {
+		return err
+	}
+	zeta, err := deriveRandomness(transcript, "zeta", &proof.Quotient)
+	if err != nil {
+		return err
+	}
+
+	// check the opening proofs
+	err = kzg.BatchVerifySinglePoint(digests, &proof.BatchedProof, zeta, hFunc, vk)
+	if err != nil {
+		return err
+	}
+	generator, err := fft.Generator(proof.Size)
+	if err != nil {
+		return err
+	}
+	var shiftedZeta fr.Element
+	shiftedZeta.Mul(&zeta, &generator)
+	err = kzg.Verify(&proof.Z, &proof.ZShiftedProof, shiftedZeta, vk)
+	if err != nil {
+		return err
+	}
+
+	// unpack the claimed values
+	claimedValues := proof.BatchedProof.ClaimedValues
+	t := compressEvaluations(claimedValues[:nbColumns], lambda)
+	claimedValues = claimedValues[nbColumns:]
+	fs := make([]fr.Element, nbWitnesses)
+	for k := range fs {
+		fs[k] = compressEvaluations(claimedValues[:nbColumns], lambda)
+		claimedValues = claimedValues[nbColumns:]
+	}
+	m := claimedValues[0]
+	hs := claimedValues[1 : 1+nbWitnesses]
+	ht := claimedValues[1+nbWitnesses]
+	z := claimedValues[2+nbWitnesses]
+	q := claimedValues[3+nbWitnesses]
+
+	// Z(ωζ) - Z(ζ) - ∑ₖhₖ(ζ) + hₜ(ζ) + ∑ₖαᵏ⁺¹(hₖ(ζ)(β-fₖ(ζ)) - 1) + αᴷ⁺¹(hₜ(ζ)(β-t(ζ)) - m(ζ))
+	var num, acc, c, one fr.Element
+	one.SetOne()
+	num.Sub(&proof.ZShiftedProof.ClaimedValue, &z).Add(&num, &ht)
+	for k := range hs {
+		num.Sub(&num, &hs[k])
+	}
+	acc.Set(&alpha)
+	for k := range hs {
+		c.Sub(&beta, &fs[k]).Mul(&c, &hs[k]).Sub(&c, &one).Mul(&c, &acc)
+		num.Add(&num, &c)
+		acc.Mul(&acc, &alpha)
+	}
+	c.Sub(&beta, &t).Mul(&c, &ht).Sub(&c, &m).Mul(&c, &acc)
+	num.Add(&num, &c)
+
+	// (ζⁿ-1)⋅Q(ζ)
+	var zn fr.Element
+	zn.Exp(zeta, big.NewInt(int64(proof.Size))).Sub(&zn, &one).Mul(&zn, &q)
+	if !num.Equal(&zn) {
+		return ErrLogUpVerification
+	}
+
+	return nil
+}
+
+// columnDigests returns the commitments to the columns of the table, of the
+// witnesses, and to the multiplicities.
+func (proof *ProofLookup) columnDigests() []*bls12377.G1Affine {
+	res := make([]*bls12377.G1Affine, 0, len(proof.Table)*(len(proof.Witnesses)+1)+1)
+	for j := range proof.Table {
+		res = append(res, &proof.Table[j])
+	}
+	for k := range proof.Witnesses {
+		for j := range proof.Witnesses[k] {
+			res = append(res, &proof.Witnesses[k][j])
+		}
+	}
+	return append(res, &proof.Multiplicities)
+}
+
+// openedDigests returns the commitments that are opened at ζ, in order.
+func (proof *ProofLookup) openedDigests() []kzg.Digest {
+	res := make([]kzg.Digest, 0, len(proof.Table)*(len(proof.Witnesses)+1)+len(proof.Inverses)+4)
+	res = append(res, proof.Table...)
+	for k := range proof.Witnesses {
+		res = append(res, proof.Witnesses[k]...)
+	}
+	res = append(res, proof.Multiplicities)
+	res = append(res, proof.Inverses...)
+	return append(res, proof.TableInverse, proof.Z, proof.Quotient)
+}
+
+// computeQuotientCanonical computes the quotient of the LogUp constraints by
+// Xⁿ-1, folded with alpha. The inputs are evaluated on the coset of domainBig,
+// in bit reversed order. It returns the quotient, in canonical basis.
+func computeQuotientCanonical(alpha, beta, lambda fr.Element, lt [][]fr.Element, lfs [][][]fr.Element, lm []fr.Element, lhs [][]fr.Element, lht, lz []fr.Element, domainBig *fft.Domain) []fr.Element {
+
+	sizeDomainBig := int(domainBig.Cardinality)
+	res := make([]fr.Element, sizeDomainBig)
+
+	var one fr.Element
+	one.SetOne()
+
+	numLn := evaluateXnMinusOneDomainBig(domainBig)
+	numLn[0].Inverse(&numLn[0])
+	numLn[1].Inverse(&numLn[1])
+	nn := uint64(64 - bits.TrailingZeros64(domainBig.Cardinality))
+
+	row := make([]fr.Element, len(lt))
+	for i := 0; i < sizeDomainBig; i++ {
+
+		_i := int(bits.Reverse64(uint64(i)) >> nn)
+		_is := int(bits.Reverse64(uint64((i+2)%sizeDomainBig)) >> nn)
+
+		// Z(ωx) - Z(x) - ∑ₖhₖ(x) + hₜ(x)
+		res[_i].Sub(&lz[_is], &lz[_i]).Add(&res[_i], &lht[_i])
+		for k := range lhs {
+			res[_i].Sub(&res[_i], &lhs[k][_i])
+		}
+
+		// ∑ₖαᵏ⁺¹(hₖ(x)(β-fₖ(x)) - 1)
+		var acc, c fr.Element
+		acc.Set(&alpha)
+		for k := range lfs {
+			for j := range row {
+				row[j] = lfs[k][j][_i]
+			}
+			c = compressEvaluations(row, lambda)
+			c.Sub(&beta, &c).Mul(&c, &lhs[k][_i]).Sub(&c, &one).Mul(&c, &acc)
+			res[_i].Add(&res[_i], &c)
+			acc.Mul(&acc, &alpha)
+		}
+
+		// αᴷ⁺¹(hₜ(x)(β-t(x)) - m(x))
+		for j := range row {
+			row[j] = lt[j][_i]
+		}
+		c = compressEvaluations(row, lambda)
+		c.Sub(&beta, &c).Mul(&c, &lht[_i]).Sub(&c, &lm[_i]).Mul(&c, &acc)
+		res[_i].Add(&res[_i], &c).
+			Mul(&res[_i], &numLn[i%2])
+	}
+
+	domainBig.FFTInverse(res, fft.DIT, fft.OnCoset())
+
+	return res
+}
+
+// evaluateXnMinusOneDomainBig returns the evaluation of (x^{n}-1) on FrMultiplicativeGen*< g  >
+func evaluateXnMinusOneDomainBig(domainBig *fft.Domain) [2]fr.Element {
+
+	sizeDomainSmall := domainBig.Cardinality / 2
+
+	var one fr.Element
+	one.SetOne()
+
+	// x^{n}-1 on FrMultiplicativeGen*< g  >
+	var res [2]fr.Element
+	var shift fr.Element
+	shift.Exp(domainBig.FrMultiplicativeGen, big.NewInt(int64(sizeDomainSmall)))
+	res[0].Sub(&shift, &one)
+	res[1].Add(&shift, &one).Neg(&res[1])
+
+	return res
+
+}
+
+// evaluateOnCoset returns the evaluations of the polynomial of coefficients
+// p on the coset of domainBig, in bit reversed order.
+func evaluateOnCoset(p []fr.Element, domainBig *fft.Domain) []fr.Element {
+	res := make([]fr.Element, domainBig.Cardinality)
+	copy(res, p)
+	domainBig.FFT(res, fft.DIF, fft.OnCoset())
+	return res
+}
+
+// commitLagrange returns the coefficients of the polynomial whose evaluations
+// on domain are l, and its commitment.
+func commitLagrange(l []fr.Element, domain *fft.Domain, pk kzg.ProvingKey) ([]fr.Element, kzg.Digest, error) {
+	c := make([]fr.Element, len(l))
+	copy(c, l)
+	domain.FFTInverse(c, fft.DIF)
+	fft.BitReverse(c)
+	d, err := kzg.Commit(c, pk)
+	return c, d, err
+}
+
+// inverseOfShifted returns 1/(β-v[i]) for each i
+func inverseOfShifted(v fr.Vector, beta fr.Element) fr.Vector {
+	res := make(fr.Vector, len(v))
+	for i := range v {
+		res[i].Sub(&beta, &v[i])
+	}
+	return fr.BatchInvert(res)
+}
+
+// pad returns a copy of v of size n, completed with padding.
+func pad(v fr.Vector, padding fr.Element, n int) fr.Vector {
+	res := make(fr.Vector, n)
+	copy(res, v)
+	for i := len(v); i < n; i++ {
+		res[i] = padding
+	}
+	return res
+}
+
+// bindSize binds the size of the domain to the first challenge
+func bindSize(transcript *fiatshamir.Transcript, size uint64) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], size)
+	return transcript.Bind("lambda", buf[:])
+}
+
+func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
+
+	var buf [bls12377.SizeOfG1AffineUncompressed]byte
+	var r fr.Element
+
+	for _, p := range points {
+		buf = p.RawBytes()
+		if err := fs.Bind(challenge, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge(challenge)
+	if err != nil {
+		return r, err
+	}
+	r.SetBytes(b)
+	return r, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package permutation
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+// serializationVersion is the version of the binary encoding of the proofs,
+// written as their first byte.
+const serializationVersion uint8 = 1
+
+var errUnsupportedVersion = errors.New("unsupported serialization version")
+
+// WriteTo writes binary encoding of the Proof
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of the Proof to w without point compression
+func (proof *Proof) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, bls12377.RawEncoding())
+}
+
+func (proof *Proof) writeTo(w io.Writer, options ...func(*bls12377.Encoder)) (int64, error) {
+	enc := bls12377.NewEncoder(w, options...)
+
+	toEncode := []interface{}{
+		serializationVersion,
+		uint64(proof.size),
+		&proof.g,
+		&proof.t1,
+		&proof.t2,
+		&proof.z,
+		&proof.q,
+		&proof.batchedProof.H,
+		proof.batchedProof.ClaimedValues,
+		&proof.shiftedProof.H,
+		&proof.shiftedProof.ClaimedValue,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a Proof from r, in compressed or raw form
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+
+	var version uint8
+	if err := dec.Decode(&version); err != nil {
+		return dec.BytesRead(), err
+	}
+	if version != serializationVersion {
+		return dec.BytesRead(), errUnsupportedVersion
+	}
+
+	var size uint64
+	toDecode := []interface{}{
+		&size,
+		&proof.g,
+		&proof.t1,
+		&proof.t2,
+		&proof.z,
+		&proof.q,
+		&proof.batchedProof.H,
+		&proof.batchedProof.ClaimedValues,
+		&proof.shiftedProof.H,
+		&proof.shiftedProof.ClaimedValue,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	proof.size = int(size)
+
+	return dec.BytesRead(), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/test_vector_utils"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+func TestSerialization(t *testing.T) {
+
+	poly := make(polynomial.MultiLin, 16)
+	for i := range poly {
+		poly[i].SetUint64(uint64(i*i + 1))
+	}
+
+	// the verifier computes the final evaluation itself
+	claim := singleMultilinClaim{g: poly.Clone()}
+	proof, err := Prove(&claim, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("proof round-trip", testutils.SerializationRoundTrip(&proof))
+
+	// the prover provides the final evaluation
+	proof.FinalEvalProof = []fr.Element{poly[0], poly[1]}
+	t.Run("proof with final evaluation round-trip", testutils.SerializationRoundTrip(&proof))
+
+	proof.FinalEvalProof = "unsupported"
+	if _, err = proof.WriteTo(new(bytes.Buffer)); err != errUnsupportedFinalEvalProof {
+		t.Fatal("expected errUnsupportedFinalEvalProof")
+	}
+
+	// length prefixes larger than the input aren't allocated upfront
+	for _, b := range [][]byte{
+		{serializationVersion, 0xff, 0xff, 0xff, 0xff},
+		{serializationVersion, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff},
+	} {
+		if _, err = proof.ReadFrom(bytes.NewReader(b)); err != io.EOF {
+			t.Fatalf("expected io.EOF, got %v", err)
+		}
+	}
+}
@@ -210,6 +210,7 @@ func TestG1AffineConversions(t *testing.T) {
 		GenFp(),
 		GenFp(),
 	))
+
 	properties.Property("[BLS12-377] BatchJacobianToAffineG1 and FromJacobian should output the same result", prop.ForAll(
 		func(a, b fp.Element) bool {
 			g1 := fuzzG1Jac(&g1Gen, a)
@@ -0,0 +1,17 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ipa provides a transparent polynomial commitment scheme
+// based on the inner product argument (IPA) of Bulletproofs, as used in Halo.
+//
+// A polynomial p of degree < n is committed to with a Pedersen vector
+// commitment C = ∑ᵢ pᵢGᵢ, where the bases Gᵢ are derived with HashToG1, so
+// that no trusted setup is needed and the curve needs no pairing.
+//
+// An opening proof of p(z) = v shows that ⟨p, (1, z, …, zⁿ⁻¹)⟩ = v in
+// log₂(n) rounds, each halving the size of the vectors. Verifying a proof
+// costs one multi-exponentiation of size n; BatchVerify checks several proofs
+// with a single multi-exponentiation by merging the scalars of the shared bases.
+package ipa
@@ -0,0 +1,441 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of proofs or points")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (larger than SRS or == 0)")
+	ErrInvalidProofSize      = errors.New("number of rounds of the proof doesn't match the SRS size")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrSRSSize               = errors.New("srs size must be a power of 2, at least 2")
+	ErrZeroChallenge         = errors.New("challenge is zero")
+)
+
+// Digest commitment of a polynomial.
+type Digest = bls12377.G1Affine
+
+// SRS holds the public parameters of the scheme. They are transparent:
+// the bases are derived with HashToG1, nobody knows their discrete logarithms.
+//
+// implements io.ReaderFrom and io.WriterTo
+type SRS struct {
+	G []bls12377.G1Affine // [G₀, G₁, ..., Gₙ₋₁] bases of the commitments
+	U bls12377.G1Affine   // base of the inner product
+}
+
+// NewSRS derives an SRS of the given size, which must be a power of 2.
+//
+// The bases are HashToG1("G" ‖ i, domainSeparator) and
+// U = HashToG1("U", domainSeparator), so that anyone can recompute them.
+func NewSRS(size uint64, domainSeparator []byte) (*SRS, error) {
+	if size < 2 || size&(size-1) != 0 {
+		return nil, ErrSRSSize
+	}
+
+	var srs SRS
+	srs.G = make([]bls12377.G1Affine, size)
+
+	var err error
+	if srs.U, err = bls12377.HashToG1([]byte("U"), domainSeparator); err != nil {
+		return nil, err
+	}
+
+	chErr := make(chan error, 1)
+	parallel.Execute(int(size), func(start, end int) {
+		msg := make([]byte, 0, 9)
+		for i := start; i < end; i++ {
+			msg = binary.BigEndian.AppendUint64(append(msg[:0], 'G'), uint64(i))
+			g, err := bls12377.HashToG1(msg, domainSeparator)
+			if err != nil {
+				select {
+				case chErr <- err:
+				default:
+				}
+				return
+			}
+			srs.G[i] = g
+		}
+	})
+	close(chErr)
+	if err := <-chErr; err != nil {
+		return nil, err
+	}
+
+	return &srs, nil
+}
+
+// OpeningProof IPA proof for opening a polynomial at a single point.
+//
+// implements io.ReaderFrom and io.WriterTo
+type OpeningProof struct {
+	// L, R cross terms of each round of the argument
+	L, R []bls12377.G1Affine
+
+	// A the committed vector, folded down to a single scalar
+	A fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// Commit commits to a polynomial using a multi exponentiation with the SRS.
+// It is assumed that the polynomial is in canonical form, in Montgomery form.
+func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
+
+	if len(p) == 0 || len(p) > len(srs.G) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls12377.G1Affine
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(srs.G[:len(p)], p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// Open computes an opening proof of polynomial p at given point.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * p is the polynomial to open, of size at most len(srs.G)
+// * digest is the commitment to p, which binds the challenges
+// * dataTranscript extra data that might be needed to derive the challenges
+func Open(p []fr.Element, digest Digest, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) (OpeningProof, error) {
+	n := len(srs.G)
+	if len(p) == 0 || len(p) > n {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	nbRounds := bits.Len(uint(n)) - 1
+
+	// a = p padded with zeroes, b = (1, z, z², ..., zⁿ⁻¹), so that ⟨a, b⟩ = p(z)
+	a := make([]fr.Element, n)
+	copy(a, p)
+	b := make([]fr.Element, n)
+	b[0].SetOne()
+	for i := 1; i < n; i++ {
+		b[i].Mul(&b[i-1], &point)
+	}
+
+	res := OpeningProof{
+		L:            make([]bls12377.G1Affine, nbRounds),
+		R:            make([]bls12377.G1Affine, nbRounds),
+		ClaimedValue: eval(p, point),
+	}
+
+	fs := newTranscript(hf, nbRounds)
+	xi, err := deriveXi(fs, &digest, point, res.ClaimedValue, dataTranscript...)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// U' = [ξ]U binds the inner product to the challenge
+	var u bls12377.G1Affine
+	var xiBigInt big.Int
+	u.ScalarMultiplication(&srs.U, xi.BigInt(&xiBigInt))
+
+	g := make([]bls12377.G1Affine, n)
+	copy(g, srs.G)
+
+	for j := 0; j < nbRounds; j++ {
+		h := len(a) / 2
+		aLo, aHi := a[:h], a[h:]
+		bLo, bHi := b[:h], b[h:]
+		gLo, gHi := g[:h], g[h:]
+
+		// L = ⟨a_lo, G_hi⟩ + ⟨a_lo, b_hi⟩U'
+		// R = ⟨a_hi, G_lo⟩ + ⟨a_hi, b_lo⟩U'
+		if res.L[j], err = crossTerm(aLo, bHi, gHi, &u); err != nil {
+			return OpeningProof{}, err
+		}
+		if res.R[j], err = crossTerm(aHi, bLo, gLo, &u); err != nil {
+			return OpeningProof{}, err
+		}
+
+		uj, err := deriveRoundChallenge(fs, j, &res.L[j], &res.R[j])
+		if err != nil {
+			return OpeningProof{}, err
+		}
+		var ujInv fr.Element
+		ujInv.Inverse(&uj)
+
+		// a' = a_lo + u⁻¹ a_hi
+		// b' = b_lo + u b_hi
+		// G' = G_lo + u G_hi
+		// so that ⟨a', G'⟩ + ⟨a', b'⟩U' = ⟨a, G⟩ + ⟨a, b⟩U' + uL + u⁻¹R
+		var ujBigInt big.Int
+		uj.BigInt(&ujBigInt)
+		gJac := make([]bls12377.G1Jac, h)
+		parallel.Execute(h, func(start, end int) {
+			var t fr.Element
+			for i := start; i < end; i++ {
+				t.Mul(&aHi[i], &ujInv)
+				aLo[i].Add(&aLo[i], &t)
+
+				t.Mul(&bHi[i], &uj)
+				bLo[i].Add(&bLo[i], &t)
+
+				gJac[i].FromAffine(&gHi[i])
+				gJac[i].ScalarMultiplication(&gJac[i], &ujBigInt).AddMixed(&gLo[i])
+			}
+		})
+		a, b = aLo, bLo
+		g = bls12377.BatchJacobianToAffineG1(gJac)
+	}
+
+	res.A = a[0]
+
+	return res, nil
+}
+
+// Verify verifies an IPA opening proof at a single point
+func Verify(digest *Digest, proof *OpeningProof, point fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	return BatchVerify([]Digest{*digest}, []OpeningProof{*proof}, []fr.Element{point}, hf, srs, dataTranscript...)
+}
+
+// BatchVerify verifies a list of opening proofs at different points.
+// The checks of the proofs are combined with random coefficients into a single
+// multi-exponentiation, the scalars of the bases Gᵢ being merged.
+//
+// * digests list of committed polynomials
+// * proofs list of opening proofs, one for each digest
+// * points the list of points at which the opening are done
+// * dataTranscript extra data used by the prover to derive the challenges
+func BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+
+	// check consistency nb proofs vs nb digests
+	if len(digests) != len(proofs) || len(digests) != len(points) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	n := len(srs.G)
+	nbRounds := bits.Len(uint(n)) - 1
+	if n < 2 || n&(n-1) != 0 {
+		return ErrSRSSize
+	}
+	for i := range proofs {
+		if len(proofs[i].L) != nbRounds || len(proofs[i].R) != nbRounds {
+			return ErrInvalidProofSize
+		}
+	}
+
+	// For each proof, with s the vector such that G_final = ∑ᵢsᵢGᵢ, the
+	// verifier checks
+	// a·⟨s, G⟩ + ξ(a·b_final - v)U - C - ∑ⱼ(uⱼLⱼ + uⱼ⁻¹Rⱼ) = 0
+	// The checks are scaled by random λ, which is 1 for the first proof.
+	nbPoints := n + 1 + len(proofs)*(1+2*nbRounds)
+	bases := make([]bls12377.G1Affine, n+1, nbPoints)
+	scalars := make([]fr.Element, n+1, nbPoints)
+	copy(bases, srs.G)
+	bases[n] = srs.U
+
+	var lambda, t fr.Element
+	for k := range proofs {
+		if k == 0 {
+			lambda.SetOne()
+		} else if _, err := lambda.SetRandom(); err != nil {
+			return err
+		}
+
+		proof := &proofs[k]
+		fs := newTranscript(hf, nbRounds)
+		xi, err := deriveXi(fs, &digests[k], points[k], proof.ClaimedValue, dataTranscript...)
+		if err != nil {
+			return err
+		}
+		u := make([]fr.Element, nbRounds)
+		for j := range u {
+			if u[j], err = deriveRoundChallenge(fs, j, &proof.L[j], &proof.R[j]); err != nil {
+				return err
+			}
+		}
+		uInv := fr.BatchInvert(u)
+
+		// λa·s
+		var la fr.Element
+		la.Mul(&lambda, &proof.A)
+		s := foldingCoefficients(u, la)
+		for i := range s {
+			scalars[i].Add(&scalars[i], &s[i])
+		}
+
+		// b_final = ∏ⱼ(1 + uⱼz^(2ᵏ⁻¹⁻ʲ))
+		var bFinal, one fr.Element
+		bFinal.SetOne()
+		one.SetOne()
+		z := points[k]
+		for j := nbRounds - 1; j >= 0; j-- {
+			t.Mul(&u[j], &z).Add(&t, &one)
+			bFinal.Mul(&bFinal, &t)
+			z.Square(&z)
+		}
+
+		// λξ(a·b_final - v) U
+		t.Mul(&proof.A, &bFinal).Sub(&t, &proof.ClaimedValue).Mul(&t, &xi).Mul(&t, &lambda)
+		scalars[n].Add(&scalars[n], &t)
+
+		// -λC
+		t.Neg(&lambda)
+		bases = append(bases, digests[k])
+		scalars = append(scalars, t)
+
+		// -λuⱼLⱼ, -λuⱼ⁻¹Rⱼ
+		for j := 0; j < nbRounds; j++ {
+			t.Mul(&u[j], &lambda).Neg(&t)
+			bases = append(bases, proof.L[j])
+			scalars = append(scalars, t)
+
+			t.Mul(&uInv[j], &lambda).Neg(&t)
+			bases = append(bases, proof.R[j])
+			scalars = append(scalars, t)
+		}
+	}
+
+	var check bls12377.G1Jac
+	if _, err := check.MultiExp(bases, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !check.Z.IsZero() {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// foldingCoefficients returns c·s, where s is the vector such that the bases
+// folded with the challenges u are ∑ᵢsᵢGᵢ: sᵢ is the product of the uⱼ such
+// that the bit of i that the round j splits on (the most significant first) is
+// set.
+func foldingCoefficients(u []fr.Element, c fr.Element) []fr.Element {
+	s := make([]fr.Element, 1, 1<<len(u))
+	s[0] = c
+	for j := range u {
+		s = s[:2*len(s)]
+		for i := len(s)/2 - 1; i >= 0; i-- {
+			s[2*i+1].Mul(&s[i], &u[j])
+			s[2*i] = s[i]
+		}
+	}
+	return s
+}
+
+// crossTerm returns ⟨a, G⟩ + ⟨a, b⟩U
+func crossTerm(a, b []fr.Element, g []bls12377.G1Affine, u *bls12377.G1Affine) (bls12377.G1Affine, error) {
+	var ip, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		ip.Add(&ip, &t)
+	}
+
+	var res bls12377.G1Affine
+	if _, err := res.MultiExp(g, a, ecc.MultiExpConfig{}); err != nil {
+		return res, err
+	}
+	var ipU bls12377.G1Affine
+	var ipBigInt big.Int
+	ipU.ScalarMultiplication(u, ip.BigInt(&ipBigInt))
+	res.Add(&res, &ipU)
+
+	return res, nil
+}
+
+// eval returns p(point) where p is interpreted as a polynomial
+// ∑_{i<len(p)}p[i]Xⁱ
+func eval(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	n := len(p)
+	res.Set(&p[n-1])
+	for i := n - 2; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
+
+// newTranscript returns the Fiat Shamir transcript of an opening proof:
+// ξ, then one challenge per round.
+func newTranscript(hf hash.Hash, nbRounds int) *fiatshamir.Transcript {
+	challenges := make([]string, nbRounds+1)
+	challenges[0] = "xi"
+	for j := 0; j < nbRounds; j++ {
+		challenges[j+1] = roundChallengeID(j)
+	}
+	return fiatshamir.NewTranscript(hf, challenges...)
+}
+
+func roundChallengeID(j int) string {
+	return "u" + strconv.Itoa(j)
+}
+
+// deriveXi derives the challenge ξ, binded to the digest, the point and the
+// claimed value.
+func deriveXi(fs *fiatshamir.Transcript, digest *Digest, point, claimedValue fr.Element, dataTranscript ...[]byte) (fr.Element, error) {
+	bDigest := digest.RawBytes()
+	if err := fs.Bind("xi", bDigest[:]); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind("xi", point.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind("xi", claimedValue.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	for i := 0; i < len(dataTranscript); i++ {
+		if err := fs.Bind("xi", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return computeChallenge(fs, "xi")
+}
+
+// deriveRoundChallenge derives the challenge uⱼ of round j, binded to the
+// cross terms of the round (and the previous challenges).
+func deriveRoundChallenge(fs *fiatshamir.Transcript, j int, l, r *bls12377.G1Affine) (fr.Element, error) {
+	id := roundChallengeID(j)
+	bL, bR := l.RawBytes(), r.RawBytes()
+	if err := fs.Bind(id, bL[:]); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(id, bR[:]); err != nil {
+		return fr.Element{}, err
+	}
+	return computeChallenge(fs, id)
+}
+
+func computeChallenge(fs *fiatshamir.Transcript, id string) (fr.Element, error) {
+	b, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var c fr.Element
+	c.SetBytes(b)
+	if c.IsZero() {
+		return fr.Element{}, ErrZeroChallenge
+	}
+	return c, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidCosetSize     = errors.New("coset size must be a power of 2 dividing the domain cardinality")
+	ErrInvalidDomainSize    = errors.New("domain cardinality must be larger than the polynomial size")
+	ErrInvalidNbEvaluations = errors.New("number of evaluations must be a power of 2, smaller than the number of G1 points")
+)
+
+// FK20Setup stores the data, derived from the proving key, needed to compute with the
+// Feist-Khovratovich algorithm the opening proofs of a polynomial on all the cosets of
+// size cosetSize of an FFT domain.
+//
+// Let N be the cardinality of the domain, ω its generator, and l the coset size.
+// The domain is split in m = N/l cosets ωⁱ⟨ω^{m}⟩, i < m. The proof for the i-th coset is
+// the commitment to the quotient of the polynomial by X^l-ω^{il}. When l = 1, it is the
+// quotient H of the OpeningProof at ωⁱ.
+//
+// Computing the m proofs costs O(n log n) group operations instead of O(n²) with Open.
+//
+// See https://eprint.iacr.org/2023/033
+type FK20Setup struct {
+	domain          *fft.Domain
+	circulantDomain *fft.Domain
+	polySize        uint64
+	cosetSize       uint64
+	nbChunks        uint64
+
+	// srsFFT[p][r] is the p-th entry of the Fourier transform of the reversed
+	// strided SRS [α^{l(T-1-i)+r}]G₁, i < T.
+	srsFFT [][]bls12377.G1Affine
+}
+
+// NewFK20Setup precomputes the data needed to compute the proofs of polynomials of
+// at most polySize coefficients, on the cosets of size cosetSize of domain.
+func NewFK20Setup(pk ProvingKey, polySize uint64, domain *fft.Domain, cosetSize uint64) (*FK20Setup, error) {
+	if polySize == 0 || polySize > uint64(len(pk.G1)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+	if domain.Cardinality < polySize {
+		return nil, ErrInvalidDomainSize
+	}
+	if cosetSize == 0 || bits.OnesCount64(cosetSize) != 1 || domain.Cardinality%cosetSize != 0 {
+		return nil, ErrInvalidCosetSize
+	}
+
+	// the polynomial is split in T chunks of size l, the proofs are computed
+	// from l Toeplitz matrix-vector products of size T, embedded in circulant
+	// matrices of size M ≥ 2T.
+	nbChunks := (polySize + cosetSize - 1) / cosetSize
+	circulantSize := 2 * ecc.NextPowerOfTwo(nbChunks)
+
+	s := &FK20Setup{
+		domain:          domain,
+		circulantDomain: fft.NewDomain(circulantSize),
+		polySize:        polySize,
+		cosetSize:       cosetSize,
+		nbChunks:        nbChunks,
+		srsFFT:          make([][]bls12377.G1Affine, circulantSize),
+	}
+	for p := range s.srsFFT {
+		s.srsFFT[p] = make([]bls12377.G1Affine, cosetSize)
+	}
+
+	for r := uint64(0); r < cosetSize; r++ {
+		// ũᵢ = [α^{l(T-1-i)+r}]G₁ for 1 ≤ i < T, 0 otherwise
+		u := make([]bls12377.G1Jac, circulantSize)
+		for i := uint64(1); i < nbChunks; i++ {
+			u[i].FromAffine(&pk.G1[cosetSize*(nbChunks-1-i)+r])
+		}
+		fftG1(u, s.circulantDomain.Generator)
+		uAff := bls12377.BatchJacobianToAffineG1(u)
+		for p := range uAff {
+			s.srsFFT[p][r] = uAff[p]
+		}
+	}
+
+	return s, nil
+}
+
+// ComputeProofs returns the opening proofs of p on the cosets ωⁱ⟨ω^{N/l}⟩ for i < N/l,
+// in natural order. p is in canonical form, in Montgomery form.
+func (s *FK20Setup) ComputeProofs(p []fr.Element) ([]Digest, error) {
+	if len(p) == 0 || uint64(len(p)) > s.polySize {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	l, T := s.cosetSize, s.nbChunks
+	M := s.circulantDomain.Cardinality
+
+	// v⁽ʳ⁾ₜ = p_{lt+r}, and its Fourier transform
+	v := make([][]fr.Element, l)
+	parallel.Execute(int(l), func(start, end int) {
+		for r := start; r < end; r++ {
+			v[r] = make([]fr.Element, M)
+			for t := uint64(0); t < T; t++ {
+				if idx := l*t + uint64(r); idx < uint64(len(p)) {
+					v[r][t] = p[idx]
+				}
+			}
+			s.circulantDomain.FFT(v[r], fft.DIF)
+			fft.BitReverse(v[r])
+		}
+	})
+
+	// Ĉₚ = ∑ᵣv̂⁽ʳ⁾ₚ[ũ⁽ʳ⁾]ₚ
+	c := make([]bls12377.G1Jac, M)
+	errs := make([]error, M)
+	parallel.Execute(int(M), func(start, end int) {
+		scalars := make([]fr.Element, l)
+		for q := start; q < end; q++ {
+			for r := range scalars {
+				scalars[r] = v[r][q]
+			}
+			_, errs[q] = c[q].MultiExp(s.srsFFT[q], scalars, ecc.MultiExpConfig{NbTasks: 1})
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// C = FFT⁻¹(Ĉ), and Hₖ = C_{k+T} for k < T-1
+	fftG1(c, s.circulantDomain.GeneratorInv)
+	nbCosets := s.domain.Cardinality / l
+	h := make([]bls12377.G1Jac, nbCosets)
+	var bCardinalityInv big.Int
+	s.circulantDomain.CardinalityInv.BigInt(&bCardinalityInv)
+	parallel.Execute(int(T-1), func(start, end int) {
+		for k := start; k < end; k++ {
+			h[k].ScalarMultiplication(&c[uint64(k)+T], &bCardinalityInv)
+		}
+	})
+
+	// the proof for the i-th coset is ∑ₖ(ω^{il})ᵏHₖ
+	var generator fr.Element
+	generator.Exp(s.domain.Generator, new(big.Int).SetUint64(l))
+	fftG1(h, generator)
+
+	return bls12377.BatchJacobianToAffineG1(h), nil
+}
+
+// VerifyCosetProof verifies an opening proof computed by FK20Setup.ComputeProofs, attesting
+// that the polynomial committed in commitment evaluates to evaluations[j] at shift⋅wʲ,
+// where w is the root of unity of order l = len(evaluations).
+//
+// * g1 holds at least the first l points of the proving key [G₁, [α]G₁, .., [α^{l-1}]G₁]
+// * g2CosetSize is [α^l]G₂
+func VerifyCosetProof(commitment, proof *Digest, shift fr.Element, evaluations []fr.Element, g1 []bls12377.G1Affine, g2CosetSize bls12377.G2Affine, vk VerifyingKey) error {
+	l := uint64(len(evaluations))
+	if l == 0 || bits.OnesCount64(l) != 1 || l > uint64(len(g1)) {
+		return ErrInvalidNbEvaluations
+	}
+
+	// interpolate the evaluations: r(shift⋅Y) = ∑ₖcₖYᵏ = FFT⁻¹(evaluations)
+	coeffs := make([]fr.Element, l)
+	copy(coeffs, evaluations)
+	fft.NewDomain(l).FFTInverse(coeffs, fft.DIF)
+	fft.BitReverse(coeffs)
+	var shiftInv, acc fr.Element
+	shiftInv.Inverse(&shift)
+	acc.SetOne()
+	for k := range coeffs {
+		coeffs[k].Mul(&coeffs[k], &acc)
+		acc.Mul(&acc, &shiftInv)
+	}
+
+	// [f(α) - r(α)]G₁
+	var remainder, totalG1 bls12377.G1Affine
+	if _, err := remainder.MultiExp(g1[:l], coeffs, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	totalG1.Sub(commitment, &remainder)
+
+	// [α^l - shift^l]G₂
+	var shiftL fr.Element
+	var bShiftL big.Int
+	shiftL.Exp(shift, new(big.Int).SetUint64(l)).BigInt(&bShiftL)
+	var vanishingG2 bls12377.G2Affine
+	vanishingG2.ScalarMultiplication(&vk.G2[0], &bShiftL)
+	vanishingG2.Sub(&g2CosetSize, &vanishingG2)
+
+	// e([f(α) - r(α)]G₁, G₂).e([-H(α)]G₁, [α^l - shift^l]G₂) == 1
+	var proofNeg bls12377.G1Affine
+	proofNeg.Neg(proof)
+	check, err := bls12377.PairingCheck(
+		[]bls12377.G1Affine{totalG1, proofNeg},
+		[]bls12377.G2Affine{vk.G2[0], vanishingG2},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
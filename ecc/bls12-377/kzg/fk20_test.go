@@ -0,0 +1,115 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+)
+
+func TestFK20SinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	const polySize, domainSize = 32, 64
+	domain := fft.NewDomain(domainSize)
+	fk, err := NewFK20Setup(testSrs.Pk, polySize, domain, 1)
+	assert.NoError(err)
+
+	// a polynomial smaller than the setup size is padded with zeroes
+	f := randomPolynomial(polySize - 5)
+	proofs, err := fk.ComputeProofs(f)
+	assert.NoError(err)
+	assert.Equal(domainSize, len(proofs))
+
+	var point fr.Element
+	point.SetOne()
+	for i := range proofs {
+		expected, err := Open(f, point, testSrs.Pk)
+		assert.NoError(err)
+		assert.True(expected.H.Equal(&proofs[i]), "proof %d", i)
+		point.Mul(&point, &domain.Generator)
+	}
+}
+
+func TestFK20Cosets(t *testing.T) {
+	assert := require.New(t)
+
+	const polySize, domainSize, cosetSize = 32, 64, 4
+	const nbCosets = domainSize / cosetSize
+	domain := fft.NewDomain(domainSize)
+	fk, err := NewFK20Setup(testSrs.Pk, polySize, domain, cosetSize)
+	assert.NoError(err)
+
+	f := randomPolynomial(polySize)
+	digest, err := Commit(f, testSrs.Pk)
+	assert.NoError(err)
+	proofs, err := fk.ComputeProofs(f)
+	assert.NoError(err)
+	assert.Equal(nbCosets, len(proofs))
+
+	// [α^l]G₂
+	var g2CosetSize bls12377.G2Affine
+	g2CosetSize.ScalarMultiplication(&testSrs.Vk.G2[0], new(big.Int).Exp(bAlpha, big.NewInt(cosetSize), nil))
+
+	var w, shift fr.Element
+	w.Exp(domain.Generator, big.NewInt(nbCosets))
+	shift.SetOne()
+	evaluations := make([]fr.Element, cosetSize)
+	for i := range proofs {
+		// evaluations of f on shift⋅⟨w⟩
+		var x fr.Element
+		x.Set(&shift)
+		for j := range evaluations {
+			evaluations[j] = eval(f, x)
+			x.Mul(&x, &w)
+		}
+		assert.NoError(VerifyCosetProof(&digest, &proofs[i], shift, evaluations, testSrs.Pk.G1, g2CosetSize, testSrs.Vk), "proof %d", i)
+
+		// wrong evaluation
+		evaluations[0].Double(&evaluations[0])
+		assert.ErrorIs(VerifyCosetProof(&digest, &proofs[i], shift, evaluations, testSrs.Pk.G1, g2CosetSize, testSrs.Vk), ErrVerifyOpeningProof)
+
+		shift.Mul(&shift, &domain.Generator)
+	}
+}
+
+func TestFK20InvalidParameters(t *testing.T) {
+	assert := require.New(t)
+
+	domain := fft.NewDomain(32)
+	_, err := NewFK20Setup(testSrs.Pk, 64, domain, 1)
+	assert.ErrorIs(err, ErrInvalidDomainSize)
+	_, err = NewFK20Setup(testSrs.Pk, 16, domain, 3)
+	assert.ErrorIs(err, ErrInvalidCosetSize)
+	_, err = NewFK20Setup(testSrs.Pk, uint64(len(testSrs.Pk.G1)+1), fft.NewDomain(uint64(2*len(testSrs.Pk.G1))), 1)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+
+	fk, err := NewFK20Setup(testSrs.Pk, 16, domain, 2)
+	assert.NoError(err)
+	_, err = fk.ComputeProofs(randomPolynomial(17))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func BenchmarkFK20(b *testing.B) {
+	const polySize = 128
+	domain := fft.NewDomain(2 * polySize)
+	fk, err := NewFK20Setup(testSrs.Pk, polySize, domain, 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	f := randomPolynomial(polySize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = fk.ComputeProofs(f)
+	}
+}
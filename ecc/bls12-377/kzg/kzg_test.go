@@ -78,6 +78,31 @@ func TestMpcSetup(t *testing.T) {
 	}
 }
 
+func TestCheckPowers(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(testSrs.CheckPowers())
+	assert.NoError(mpcGetSrs(t).CheckPowers())
+
+	// tamper with a power
+	srs, err := NewSRS(16, bAlpha)
+	assert.NoError(err)
+	srs.Pk.G1[5] = srs.Pk.G1[4]
+	assert.Error(srs.CheckPowers())
+
+	// inconsistent G₂ power
+	srs, err = NewSRS(16, bAlpha)
+	assert.NoError(err)
+	srs.Vk.G2[1] = srs.Vk.G2[0]
+	assert.Error(srs.CheckPowers())
+
+	// wrong generator
+	srs, err = NewSRS(16, bAlpha)
+	assert.NoError(err)
+	srs.Pk.G1[0] = srs.Pk.G1[1]
+	assert.Error(srs.CheckPowers())
+}
+
 func TestToLagrangeG1(t *testing.T) {
 	assert := require.New(t)
 
@@ -154,6 +179,37 @@ func TestCommitLagrange(t *testing.T) {
 	t.Run("mpcsetup", test(mpcGetSrs(t)))
 }
 
+func TestProvingKeyLagrange(t *testing.T) {
+	assert := require.New(t)
+
+	const size = 64
+	domain := fft.NewDomain(size)
+	pkLagrange, err := testSrs.Pk.Lagrange(domain)
+	assert.NoError(err)
+	assert.Equal(size, len(pkLagrange.G1))
+
+	// same as ToLagrangeG1
+	expected, err := ToLagrangeG1(testSrs.Pk.G1[:size])
+	assert.NoError(err)
+	for i := range expected {
+		assert.True(expected[i].Equal(&pkLagrange.G1[i]), "error lagrange conversion %d", i)
+	}
+
+	// committing to the evaluations is committing to the polynomial
+	pol := randomPolynomial(size)
+	digestCanonical, err := Commit(pol, testSrs.Pk)
+	assert.NoError(err)
+	domain.FFT(pol, fft.DIF)
+	fft.BitReverse(pol)
+	digestLagrange, err := Commit(pol, pkLagrange)
+	assert.NoError(err)
+	assert.True(digestCanonical.Equal(&digestLagrange))
+
+	// the domain can't be larger than the proving key
+	_, err = testSrs.Pk.Lagrange(fft.NewDomain(uint64(2 * len(testSrs.Pk.G1))))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
 func TestDividePolyByXminusA(t *testing.T) {
 
 	const pSize = 230
@@ -14,9 +14,11 @@ import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/mpcsetup"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 	"github.com/consensys/gnark-crypto/utils"
 	"io"
 	"math/big"
+	"sync/atomic"
 )
 
 type MpcSetup struct {
@@ -168,3 +170,38 @@ func (s *MpcSetup) Seal(beaconChallenge []byte) SRS {
 
 	return s.srs
 }
+
+// CheckPowers verifies that srs is a well-formed powers-of-τ SRS, for example one imported
+// from the transcript of a public ceremony:
+//   - Pk.G1[0] and Vk.G1 are the G₁ generator, Vk.G2[0] is the G₂ generator;
+//   - all the points are in the correct subgroup;
+//   - Pk.G1[i] = [τⁱ]G₁ and Vk.G2[1] = [τ]G₂ for the same τ. This is checked with a
+//     single pairing on random linear combinations of the powers.
+func (srs *SRS) CheckPowers() error {
+	if len(srs.Pk.G1) < 2 {
+		return ErrMinSRSSize
+	}
+	_, _, g1, g2 := curve.Generators()
+	if !srs.Pk.G1[0].Equal(&g1) || !srs.Vk.G1.Equal(&g1) || !srs.Vk.G2[0].Equal(&g2) {
+		return errors.New("the SRS does not start with the generators")
+	}
+
+	if !srs.Vk.G2[1].IsInSubGroup() {
+		return errors.New("[τ]₂ representation not in subgroup")
+	}
+	var notInSubGroup atomic.Int64
+	notInSubGroup.Store(-1)
+	parallel.Execute(len(srs.Pk.G1)-1, func(start, end int) {
+		for i := start; i < end; i++ {
+			if !srs.Pk.G1[i+1].IsInSubGroup() {
+				notInSubGroup.Store(int64(i + 1))
+				return
+			}
+		}
+	})
+	if i := notInSubGroup.Load(); i != -1 {
+		return fmt.Errorf("[τ^%d]₁ representation not in subgroup", i)
+	}
+
+	return mpcsetup.SameRatioMany(srs.Pk.G1, srs.Vk.G2[:])
+}
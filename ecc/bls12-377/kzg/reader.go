@@ -0,0 +1,109 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+var (
+	ErrInvalidRange = errors.New("invalid range of proving key points")
+)
+
+// ProvingKeyReader reads sub-ranges of the G1 points of a ProvingKey serialized with
+// ProvingKey.WriteTo / WriteRawTo (or SRS.WriteTo / WriteRawTo), without loading the whole key.
+//
+// It works on any io.ReaderAt, for example an *os.File or a memory-mapped file, so that a
+// single large SRS can be shared across many circuit sizes.
+type ProvingKeyReader struct {
+	r         io.ReaderAt
+	offset    int64 // offset of the first point
+	nbPoints  int
+	pointSize int64 // SizeOfG1AffineCompressed or SizeOfG1AffineUncompressed
+}
+
+// NewProvingKeyReader returns a ProvingKeyReader for the ProvingKey serialized at offset in r.
+// The encoding (compressed or raw) is detected from the first point.
+func NewProvingKeyReader(r io.ReaderAt, offset int64) (*ProvingKeyReader, error) {
+	var bLen [4]byte
+	if _, err := r.ReadAt(bLen[:], offset); err != nil {
+		return nil, err
+	}
+	pkr := &ProvingKeyReader{
+		r:         r,
+		offset:    offset + 4,
+		nbPoints:  int(binary.BigEndian.Uint32(bLen[:])),
+		pointSize: bls12377.SizeOfG1AffineCompressed,
+	}
+	if pkr.nbPoints == 0 {
+		return pkr, nil
+	}
+
+	// the first point tells us if the points are compressed
+	var buf [bls12377.SizeOfG1AffineUncompressed]byte
+	n, err := r.ReadAt(buf[:], pkr.offset)
+	if n < bls12377.SizeOfG1AffineCompressed {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	var p bls12377.G1Affine
+	size, err := p.SetBytes(buf[:n])
+	if err != nil {
+		return nil, err
+	}
+	pkr.pointSize = int64(size)
+
+	return pkr, nil
+}
+
+// Len returns the number of G1 points in the proving key.
+func (pkr *ProvingKeyReader) Len() int {
+	return pkr.nbPoints
+}
+
+// ReadG1 reads the points [αⁱ]G₁ for start ≤ i < end, checking that they are in the
+// correct subgroup.
+func (pkr *ProvingKeyReader) ReadG1(start, end int) ([]bls12377.G1Affine, error) {
+	return pkr.readG1(start, end)
+}
+
+// UnsafeReadG1 reads the points [αⁱ]G₁ for start ≤ i < end, without checking that they
+// are in the correct subgroup.
+func (pkr *ProvingKeyReader) UnsafeReadG1(start, end int) ([]bls12377.G1Affine, error) {
+	return pkr.readG1(start, end, bls12377.NoSubgroupChecks())
+}
+
+// ReadProvingKey reads the first n points of the proving key, checking that they are in
+// the correct subgroup.
+func (pkr *ProvingKeyReader) ReadProvingKey(n int) (ProvingKey, error) {
+	g1, err := pkr.readG1(0, n)
+	return ProvingKey{G1: g1}, err
+}
+
+func (pkr *ProvingKeyReader) readG1(start, end int, options ...func(*bls12377.Decoder)) ([]bls12377.G1Affine, error) {
+	if start < 0 || end > pkr.nbPoints || start >= end {
+		return nil, ErrInvalidRange
+	}
+
+	// the decoder expects a length prefixed slice
+	var bLen [4]byte
+	binary.BigEndian.PutUint32(bLen[:], uint32(end-start))
+	section := io.NewSectionReader(pkr.r, pkr.offset+int64(start)*pkr.pointSize, int64(end-start)*pkr.pointSize)
+
+	var res []bls12377.G1Affine
+	dec := bls12377.NewDecoder(io.MultiReader(bytes.NewReader(bLen[:]), section), options...)
+	if err := dec.Decode(&res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
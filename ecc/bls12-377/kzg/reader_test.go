@@ -0,0 +1,60 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvingKeyReader(t *testing.T) {
+	test := func(write func(*SRS, *bytes.Buffer) error) func(*testing.T) {
+		return func(t *testing.T) {
+			assert := require.New(t)
+
+			// the proving key is preceded by some unrelated data
+			var buf bytes.Buffer
+			buf.WriteString("header")
+			assert.NoError(write(testSrs, &buf))
+
+			pkr, err := NewProvingKeyReader(bytes.NewReader(buf.Bytes()), int64(len("header")))
+			assert.NoError(err)
+			assert.Equal(len(testSrs.Pk.G1), pkr.Len())
+
+			for _, r := range [][2]int{{0, 1}, {0, 64}, {17, 33}, {100, len(testSrs.Pk.G1)}} {
+				g1, err := pkr.ReadG1(r[0], r[1])
+				assert.NoError(err)
+				assert.Equal(testSrs.Pk.G1[r[0]:r[1]], g1)
+
+				g1, err = pkr.UnsafeReadG1(r[0], r[1])
+				assert.NoError(err)
+				assert.Equal(testSrs.Pk.G1[r[0]:r[1]], g1)
+			}
+
+			pk, err := pkr.ReadProvingKey(32)
+			assert.NoError(err)
+			assert.Equal(testSrs.Pk.G1[:32], pk.G1)
+
+			_, err = pkr.ReadG1(10, 10)
+			assert.ErrorIs(err, ErrInvalidRange)
+			_, err = pkr.ReadG1(-1, 10)
+			assert.ErrorIs(err, ErrInvalidRange)
+			_, err = pkr.ReadG1(0, len(testSrs.Pk.G1)+1)
+			assert.ErrorIs(err, ErrInvalidRange)
+		}
+	}
+
+	t.Run("compressed", test(func(srs *SRS, buf *bytes.Buffer) error {
+		_, err := srs.WriteTo(buf)
+		return err
+	}))
+	t.Run("raw", test(func(srs *SRS, buf *bytes.Buffer) error {
+		_, err := srs.WriteRawTo(buf)
+		return err
+	}))
+}
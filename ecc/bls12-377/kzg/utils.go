@@ -14,6 +14,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
@@ -63,6 +64,39 @@ func ToLagrangeG1(coeffs []curve.G1Affine) ([]curve.G1Affine, error) {
 	return curve.BatchJacobianToAffineG1(jCoeffs), nil
 }
 
+// Lagrange returns the proving key in Lagrange form on the domain: [L₀(α)]G₁, .., [Lₙ₋₁(α)]G₁,
+// where Lᵢ is the i-th Lagrange polynomial on the n-th roots of unity of the domain.
+// Commit can then be called with the returned key on polynomials given by their
+// evaluations on the domain.
+// The proving key must have at least domain.Cardinality points.
+func (pk *ProvingKey) Lagrange(domain *fft.Domain) (ProvingKey, error) {
+	size := int(domain.Cardinality)
+	if size > len(pk.G1) {
+		return ProvingKey{}, ErrInvalidPolynomialSize
+	}
+
+	// batch convert to Jacobian
+	jCoeffs := make([]curve.G1Jac, size)
+	parallel.Execute(size, func(start, end int) {
+		for i := start; i < end; i++ {
+			jCoeffs[i].FromAffine(&pk.G1[i])
+		}
+	})
+
+	// [Lᵢ(α)]G₁ = 1/n∑ⱼω⁻ⁱʲ[αʲ]G₁
+	fftG1(jCoeffs, domain.GeneratorInv)
+
+	var invBigint big.Int
+	domain.CardinalityInv.BigInt(&invBigint)
+	parallel.Execute(size, func(start, end int) {
+		for i := start; i < end; i++ {
+			jCoeffs[i].ScalarMultiplication(&jCoeffs[i], &invBigint)
+		}
+	})
+
+	return ProvingKey{G1: curve.BatchJacobianToAffineG1(jCoeffs)}, nil
+}
+
 func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 	generator, err := fr.Generator(uint64(cardinality))
 	if err != nil {
@@ -72,6 +106,11 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 	// inverse the generator
 	generator.Inverse(&generator)
 
+	return computeTwiddles(generator, cardinality), nil
+}
+
+// computeTwiddles returns [1, w, w², ..., w^{cardinality/2}] where w is generator.
+func computeTwiddles(generator fr.Element, cardinality int) []*big.Int {
 	// nb fft stages
 	nbStages := uint64(bits.TrailingZeros64(uint64(cardinality)))
 
@@ -80,7 +119,7 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 	w := generator
 	r[0] = new(big.Int).SetUint64(1)
 	if len(r) == 1 {
-		return r, nil
+		return r
 	}
 	r[1] = new(big.Int)
 	w.BigInt(r[1])
@@ -90,7 +129,18 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 		w.BigInt(r[j])
 	}
 
-	return r, nil
+	return r
+}
+
+// fftG1 computes in place the Fourier transform of a, aᵢ ← ∑_{j}[wⁱʲ]aⱼ,
+// where w is generator, of order len(a). Input and output are in natural order.
+func fftG1(a []curve.G1Jac, generator fr.Element) {
+	numCPU := uint64(runtime.NumCPU())
+	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
+
+	twiddles := computeTwiddles(generator, len(a))
+	difFFTG1(a, twiddles, 0, maxSplits, nil)
+	bitReverse(a)
 }
 
 func bitReverse[T any](a []T) {
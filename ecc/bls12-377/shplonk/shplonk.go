@@ -13,6 +13,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 )
@@ -83,7 +84,6 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 	totalSize := maxSizePolys + nbPoints // upper bound of the size of f := ∑ᵢ γⁱZ_{T\Sᵢ}(f_i(X)-r)
 
 	bufMaxSizePolynomials := make([]fr.Element, maxSizePolys)
-	bufTotalSize := make([]fr.Element, totalSize)
 	f := make([]fr.Element, totalSize) // cf https://eprint.iacr.org/2020/081.pdf page 11 for notation
 	res.ClaimedValues = make([][]fr.Element, nbPolynomials)
 	for i := 0; i < nbPolynomials; i++ {
@@ -92,8 +92,9 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 	var accGamma fr.Element
 	accGamma.SetOne()
 
-	ztMinusSi := make([][]fr.Element, nbPolynomials)
-	ri := make([][]fr.Element, nbPolynomials)
+	ztMinusSi := make([]polynomial.Polynomial, nbPolynomials)
+	ri := make([]polynomial.Polynomial, nbPolynomials)
+	var gammaiFiMinusRi polynomial.Polynomial
 	for i := 0; i < nbPolynomials; i++ {
 
 		for j := 0; j < len(points[i]); j++ {
@@ -103,21 +104,25 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 		ztMinusSi[i] = buildZtMinusSi(points, i)
 
 		copy(bufMaxSizePolynomials, polynomials[i])
-		ri[i] = interpolate(points[i], res.ClaimedValues[i])
+		ri[i], err = polynomial.Interpolate(points[i], res.ClaimedValues[i])
+		if err != nil {
+			return res, err
+		}
 		sub(bufMaxSizePolynomials, ri[i])
 
-		bufTotalSize = mul(bufMaxSizePolynomials, ztMinusSi[i], bufTotalSize)
-		bufTotalSize = mulByConstant(bufTotalSize, accGamma)
-		for j := 0; j < len(bufTotalSize); j++ {
-			f[j].Add(&f[j], &bufTotalSize[j])
+		gammaiFiMinusRi.Mul(bufMaxSizePolynomials, ztMinusSi[i])
+		gammaiFiMinusRi.ScaleInPlace(&accGamma)
+		for j := 0; j < len(gammaiFiMinusRi); j++ {
+			f[j].Add(&f[j], &gammaiFiMinusRi[j])
 		}
 
 		accGamma.Mul(&accGamma, &gamma)
 		setZero(bufMaxSizePolynomials)
 	}
 
-	zt := buildVanishingPoly(flatten(points))
-	w := div(f, zt) // cf https://eprint.iacr.org/2020/081.pdf page 11 for notation page 11 for notation
+	// Z_{T} divides f, the remainder is zero
+	zt := polynomial.Vanishing(flatten(points))
+	w, _ := polynomial.Polynomial(f).DivRem(zt) // cf https://eprint.iacr.org/2020/081.pdf page 11 for notation
 	res.W, err = kzg.Commit(w, pk)
 	if err != nil {
 		return res, err
@@ -150,7 +155,7 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 		accGamma.Mul(&accGamma, &gamma)
 	}
 	ztz := eval(zt, z)
-	setZero(bufTotalSize)
+	bufTotalSize := make([]fr.Element, totalSize)
 	copy(bufTotalSize, w)
 	mulByConstant(bufTotalSize, ztz) // Z_{T}(z)W
 	for i := 0; i < totalSize-maxSizePolys; i++ {
@@ -160,8 +165,9 @@ func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.E
 		l[i].Sub(&l[i], &bufTotalSize[i])
 	} // L <- L-Z_{T}(z)W
 
-	xMinusZ := buildVanishingPoly([]fr.Element{z})
-	wPrime := div(l, xMinusZ)
+	// X-z divides L, the remainder is zero
+	xMinusZ := polynomial.Vanishing([]fr.Element{z})
+	wPrime, _ := polynomial.Polynomial(l).DivRem(xMinusZ)
 
 	res.WPrime, err = kzg.Commit(wPrime, pk)
 	if err != nil {
@@ -207,14 +213,17 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 	nbInstances := len(points)
 	gammaiZTminusSiz := make([]fr.Element, nbInstances)
 	accGamma.SetOne()
-	ri := make([][]fr.Element, nbInstances)
+	ri := make([]polynomial.Polynomial, nbInstances)
 	for i := 0; i < len(points); i++ {
 
 		ztMinusSi := buildZtMinusSi(points, i)                   // Z_{T-S_{i}}(X)
 		gammaiZTminusSiz[i] = eval(ztMinusSi, z)                 // Z_{T-S_{i}}(z)
 		gammaiZTminusSiz[i].Mul(&accGamma, &gammaiZTminusSiz[i]) // \gamma^{i} Z_{T-S_{i}}(z)
 
-		ri[i] = interpolate(points[i], proof.ClaimedValues[i])
+		ri[i], err = polynomial.Interpolate(points[i], proof.ClaimedValues[i])
+		if err != nil {
+			return err
+		}
 		riz := eval(ri[i], z)               // r_{i}(z)
 		tmp.Mul(&gammaiZTminusSiz[i], &riz) // Z_{T-S_{i}}(z)r_{i}(z)
 		sumGammaiZTminusSiRiz.Add(&sumGammaiZTminusSiRiz, &tmp)
@@ -239,7 +248,7 @@ func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element
 	sumGammaiZTminusSiRizCom.ScalarMultiplication(&vk.G1, &sumGammaiZTminusSiRizBigInt)
 
 	// Z_{T}(z)[W]
-	zt := buildVanishingPoly(flatten(points))
+	zt := polynomial.Vanishing(flatten(points))
 	ztz := eval(zt, z)
 	var ztW kzg.Digest
 	ztz.BigInt(&bufBigInt)
@@ -345,23 +354,8 @@ func mulByConstant(f []fr.Element, gamma fr.Element) []fr.Element {
 	return f
 }
 
-// computes f <- (x-a)*f
-// memory of f is re used, need to pass a copy to not modify it
-func multiplyLinearFactor(f []fr.Element, a fr.Element) []fr.Element {
-	s := len(f)
-	var tmp fr.Element
-	f = append(f, fr.NewElement(0))
-	f[s] = f[s-1]
-	for i := s - 1; i >= 1; i-- {
-		tmp.Mul(&f[i], &a)
-		f[i].Sub(&f[i-1], &tmp)
-	}
-	f[0].Mul(&f[0], &a).Neg(&f[0])
-	return f
-}
-
 // returns S_{T\Sᵢ} where Sᵢ=x[i]
-func buildZtMinusSi(x [][]fr.Element, i int) []fr.Element {
+func buildZtMinusSi(x [][]fr.Element, i int) polynomial.Polynomial {
 	nbPoints := 0
 	for i := 0; i < len(x); i++ {
 		nbPoints += len(x[i])
@@ -373,45 +367,7 @@ func buildZtMinusSi(x [][]fr.Element, i int) []fr.Element {
 	for j := i + 1; j < len(x); j++ {
 		bufPoints = append(bufPoints, x[j]...)
 	}
-	ztMinusSi := buildVanishingPoly(bufPoints)
-	return ztMinusSi
-}
-
-// returns πᵢ(X-xᵢ)
-func buildVanishingPoly(x []fr.Element) []fr.Element {
-	res := make([]fr.Element, 1, len(x)+1)
-	res[0].SetOne()
-	for i := 0; i < len(x); i++ {
-		res = multiplyLinearFactor(res, x[i])
-	}
-	return res
-}
-
-// returns f such that f(xᵢ)=yᵢ, x and y are assumed to be of the same size
-func interpolate(x, y []fr.Element) []fr.Element {
-
-	res := make([]fr.Element, len(x))
-	for i := 0; i < len(x); i++ {
-		li := buildLagrangeFromDomain(x, i)
-		li = mulByConstant(li, y[i])
-		for j := 0; j < len(x); j++ {
-			res[j].Add(&res[j], &li[j])
-		}
-	}
-
-	return res
-}
-
-// returns f such that f(xⱼ)=δⁱⱼ
-func buildLagrangeFromDomain(x []fr.Element, i int) []fr.Element {
-	xx := make([]fr.Element, len(x)-1)
-	copy(xx, x[:i])
-	copy(xx[i:], x[i+1:])
-	res := buildVanishingPoly(xx)
-	d := eval(res, x[i])
-	d.Inverse(&d)
-	res = mulByConstant(res, d)
-	return res
+	return polynomial.Vanishing(bufPoints)
 }
 
 // returns f-g, the memory of f is re used, deg(g) << deg(f) here
@@ -421,44 +377,3 @@ func sub(f, g []fr.Element) []fr.Element {
 	}
 	return f
 }
-
-// returns f*g using naive multiplication
-// deg(f)>>deg(g), deg(small) =~ 10 max
-// buf is used as a buffer and should not be f or g
-// f and g are not modified
-func mul(f, g []fr.Element, res []fr.Element) []fr.Element {
-
-	sizeRes := len(f) + len(g) - 1
-	if len(res) < sizeRes {
-		s := make([]fr.Element, sizeRes-len(res))
-		res = append(res, s...)
-	}
-	setZero(res)
-
-	var tmp fr.Element
-	for i := 0; i < len(g); i++ {
-		for j := 0; j < len(f); j++ {
-			tmp.Mul(&f[j], &g[i])
-			res[j+i].Add(&res[j+i], &tmp)
-		}
-	}
-	return res
-}
-
-// returns f/g (assuming g divides f)
-// OK to not use fft if deg(g) is small
-// g's leading coefficient is assumed to be 1
-// f memory is re-used for the result, need to pass a copy to not modify it
-func div(f, g []fr.Element) []fr.Element {
-	sizef := len(f)
-	sizeg := len(g)
-	stop := sizeg - +1
-	var t fr.Element
-	for i := sizef - 2; i >= stop; i-- {
-		for j := 0; j < sizeg-1; j++ {
-			t.Mul(&f[i+1], &g[sizeg-2-j])
-			f[i-j].Sub(&f[i-j], &t)
-		}
-	}
-	return f[sizeg-1:]
-}
@@ -6,6 +6,7 @@
 package eddsa
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"errors"
 	"hash"
@@ -20,6 +21,7 @@ import (
 
 var errNotOnCurve = errors.New("point not on curve")
 var errHashNeeded = errors.New("hFunc cannot be nil. We need a hash for Fiat-Shamir")
+var errMismatchedLengths = errors.New("the number of public keys, messages and signatures differ")
 
 const (
 	sizeFr         = fr.Bytes
@@ -139,24 +141,12 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 		return nil, errNotOnCurve
 	}
 
-	// compute H(R, A, M), all parameters in data are in Montgomery form
-	hFunc.Reset()
-
-	resRX := res.R.X.Bytes()
-	resRY := res.R.Y.Bytes()
-	resAX := privKey.PublicKey.A.X.Bytes()
-	resAY := privKey.PublicKey.A.Y.Bytes()
-	toWrite := [][]byte{resRX[:], resRY[:], resAX[:], resAY[:], message}
-	for _, bytes := range toWrite {
-		if _, err := hFunc.Write(bytes); err != nil {
-			return nil, err
-		}
+	// compute H(R, A, M)
+	hramInt, err := computeHRAM(&res.R, &privKey.PublicKey.A, message, hFunc)
+	if err != nil {
+		return nil, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// Compute s = randScalarInt + H(R,A,M)*S
 	// going with big int to do ops mod curve order
 	var bscalar, bs big.Int
@@ -196,26 +186,12 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 		return false, err
 	}
 
-	// compute H(R, A, M), all parameters in data are in Montgomery form
-
-	hFunc.Reset()
-
-	sigRX := sig.R.X.Bytes()
-	sigRY := sig.R.Y.Bytes()
-	sigAX := pub.A.X.Bytes()
-	sigAY := pub.A.Y.Bytes()
-
-	toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], message}
-	for _, bytes := range toWrite {
-		if _, err := hFunc.Write(bytes); err != nil {
-			return false, err
-		}
+	// compute H(R, A, M)
+	hramInt, err := computeHRAM(&sig.R, &pub.A, message, hFunc)
+	if err != nil {
+		return false, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// lhs = cofactor*S*Base
 	var lhs twistededwards.PointAffine
 	var bCofactor, bs big.Int
@@ -244,3 +220,118 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 
 	return true, nil
 }
+
+// BatchVerify verifies the eddsa signatures of messages by publicKeys, all at once.
+//
+// With random aᵢ (a₀ = 1), it checks that
+//
+// cofactor⋅(∑ aᵢ⋅Sᵢ)⋅Base = cofactor⋅(∑ aᵢ⋅Rᵢ + ∑ aᵢ⋅H(Rᵢ,Aᵢ,Mᵢ)⋅Aᵢ)
+//
+// with a single multi-scalar multiplication. If the check fails, the
+// signatures are verified one by one and a *signature.BatchError reports the
+// first invalid one.
+func BatchVerify(publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+
+	// hFunc cannot be nil.
+	// We need a hash function for the Fiat-Shamir.
+	if hFunc == nil {
+		return false, errHashNeeded
+	}
+	n := len(publicKeys)
+	if len(messages) != n || len(signatures) != n {
+		return false, errMismatchedLengths
+	}
+	if n == 0 {
+		return true, nil
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	// ∑ aᵢ⋅Sᵢ⋅Base - ∑ aᵢ⋅Rᵢ - ∑ aᵢ⋅H(Rᵢ,Aᵢ,Mᵢ)⋅Aᵢ
+	points := make([]twistededwards.PointAffine, 2*n+1)
+	scalars := make([]big.Int, 2*n+1)
+	points[0].Set(&curveParams.Base)
+
+	var randBytes [16]byte
+	for i := 0; i < n; i++ {
+		if !publicKeys[i].A.IsOnCurve() {
+			return verifyOneByOne(publicKeys, messages, signatures, hFunc)
+		}
+		var sig Signature
+		if _, err := sig.SetBytes(signatures[i]); err != nil {
+			return verifyOneByOne(publicKeys, messages, signatures, hFunc)
+		}
+		hramInt, err := computeHRAM(&sig.R, &publicKeys[i].A, messages[i], hFunc)
+		if err != nil {
+			return false, err
+		}
+
+		// aᵢ on 128 bits
+		var a big.Int
+		if i == 0 {
+			a.SetUint64(1)
+		} else {
+			if _, err := rand.Read(randBytes[:]); err != nil {
+				return false, err
+			}
+			a.SetBytes(randBytes[:])
+		}
+
+		var s big.Int
+		s.SetBytes(sig.S[:]).Mul(&s, &a)
+		scalars[0].Add(&scalars[0], &s)
+
+		points[2*i+1].Neg(&sig.R)
+		scalars[2*i+1].Set(&a)
+		points[2*i+2].Neg(&publicKeys[i].A)
+		scalars[2*i+2].Mul(&a, &hramInt).Mod(&scalars[2*i+2], &curveParams.Order)
+	}
+	scalars[0].Mod(&scalars[0], &curveParams.Order)
+
+	var res twistededwards.PointProj
+	if _, err := res.MultiExp(points, scalars); err != nil {
+		return false, err
+	}
+	var bCofactor big.Int
+	curveParams.Cofactor.BigInt(&bCofactor)
+	res.ScalarMultiplication(&res, &bCofactor)
+	if res.IsZero() {
+		return true, nil
+	}
+
+	return verifyOneByOne(publicKeys, messages, signatures, hFunc)
+}
+
+// verifyOneByOne verifies the signatures one by one, and reports the first
+// invalid one in a *signature.BatchError.
+func verifyOneByOne(publicKeys []PublicKey, messages, signatures [][]byte, hFunc hash.Hash) (bool, error) {
+	for i := range publicKeys {
+		valid, err := publicKeys[i].Verify(signatures[i], messages[i], hFunc)
+		if !valid || err != nil {
+			return false, &signature.BatchError{Index: i, Err: err}
+		}
+	}
+	return true, nil
+}
+
+// computeHRAM returns H(R, A, M), where R and A are encoded as the big endian
+// integers of their coordinates.
+func computeHRAM(R, A *twistededwards.PointAffine, message []byte, hFunc hash.Hash) (big.Int, error) {
+	var hramInt big.Int
+
+	hFunc.Reset()
+	RX := R.X.Bytes()
+	RY := R.Y.Bytes()
+	AX := A.X.Bytes()
+	AY := A.Y.Bytes()
+	toWrite := [][]byte{RX[:], RY[:], AX[:], AY[:], message}
+	for _, bytes := range toWrite {
+		if _, err := hFunc.Write(bytes); err != nil {
+			return hramInt, err
+		}
+	}
+
+	hramBin := hFunc.Sum(nil)
+	hramInt.SetBytes(hramBin)
+	return hramInt, nil
+}
@@ -7,6 +7,7 @@ package eddsa
 
 import (
 	"crypto/sha256"
+	"errors"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
 	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature"
 )
 
 func Example() {
@@ -254,8 +256,88 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestBatchVerify(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := sha256.New()
+
+	const n = 10
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	signatures := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[i] = privKey.PublicKey
+		messages[i] = []byte(fmt.Sprintf("message %d", i))
+		signatures[i], err = privKey.Sign(messages[i], hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// verifies correct signatures
+	res, err := BatchVerify(publicKeys, messages, signatures, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("BatchVerify correct signatures should return true")
+	}
+
+	// verifies a wrong msg
+	messages[3] = []byte("wrong_message")
+	res, err = BatchVerify(publicKeys, messages, signatures, hFunc)
+	if res {
+		t.Fatal("BatchVerify wrong signature should be false")
+	}
+	var batchErr *signature.BatchError
+	if !errors.As(err, &batchErr) || batchErr.Index != 3 {
+		t.Fatal("BatchVerify should report the wrong signature")
+	}
+
+	// mismatched lengths
+	if _, err = BatchVerify(publicKeys, messages[1:], signatures, hFunc); err != errMismatchedLengths {
+		t.Fatal("BatchVerify should raise mismatched lengths error")
+	}
+}
+
 // benchmarks
 
+func BenchmarkBatchVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := hash.MIMC_BLS12_381.New()
+
+	const n = 256
+	publicKeys := make([]PublicKey, n)
+	messages := make([][]byte, n)
+	signatures := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			b.Fatal(err)
+		}
+		publicKeys[i] = privKey.PublicKey
+		var frMsg fr.Element
+		frMsg.SetUint64(uint64(i))
+		msgBin := frMsg.Bytes()
+		messages[i] = msgBin[:]
+		signatures[i], _ = privKey.Sign(messages[i], hFunc)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(publicKeys, messages, signatures, hFunc)
+	}
+}
+
 func BenchmarkVerify(b *testing.B) {
 
 	src := rand.NewSource(0)
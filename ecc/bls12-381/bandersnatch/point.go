@@ -7,6 +7,7 @@ package bandersnatch
 
 import (
 	"crypto/subtle"
+	"errors"
 	"io"
 	"math/big"
 	"math/bits"
@@ -445,6 +446,66 @@ func (p *PointProj) ScalarMultiplication(p1 *PointProj, scalar *big.Int) *PointP
 	return p.scalarMulGLV(p1, scalar)
 }
 
+// MultiExp computes the multi-scalar multiplication ∑ scalars[i]⋅points[i]
+// using the bucket method.
+func (p *PointProj) MultiExp(points []PointAffine, scalars []big.Int) (*PointProj, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// negative scalars are handled by negating the points
+	_points := make([]PointAffine, len(points))
+	_scalars := make([]big.Int, len(scalars))
+	maxBits := 0
+	for i := range scalars {
+		_points[i].Set(&points[i])
+		_scalars[i].Set(&scalars[i])
+		if _scalars[i].Sign() == -1 {
+			_scalars[i].Neg(&_scalars[i])
+			_points[i].Neg(&_points[i])
+		}
+		maxBits = max(maxBits, _scalars[i].BitLen())
+	}
+
+	// window size, such that the number of buckets is about the number of points
+	c := min(max(bits.Len(uint(len(points)))-1, 1), 16)
+	buckets := make([]PointProj, (1<<c)-1)
+
+	var res PointProj
+	res.setInfinity()
+	for w := (maxBits+c-1)/c - 1; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			res.Double(&res)
+		}
+
+		for j := range buckets {
+			buckets[j].setInfinity()
+		}
+		for i := range _scalars {
+			digit := 0
+			for j := c - 1; j >= 0; j-- {
+				digit = digit<<1 | int(_scalars[i].Bit(w*c+j))
+			}
+			if digit != 0 {
+				buckets[digit-1].MixedAdd(&buckets[digit-1], &_points[i])
+			}
+		}
+
+		// ∑ (j+1)⋅buckets[j], with running sums
+		var running, sum PointProj
+		running.setInfinity()
+		sum.setInfinity()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			running.Add(&running, &buckets[j])
+			sum.Add(&sum, &running)
+		}
+		res.Add(&res, &sum)
+	}
+
+	p.Set(&res)
+	return p, nil
+}
+
 // ------- Extended coordinates
 
 // Set sets p to p1 and return it
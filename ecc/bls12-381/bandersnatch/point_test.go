@@ -730,6 +730,44 @@ func TestOps(t *testing.T) {
 		genS1,
 	))
 
+	properties.Property("multi-scalar multiplication should match the sum of scalar multiplications", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			points := make([]PointAffine, 5)
+			scalars := make([]big.Int, 5)
+			for i := range points {
+				var k big.Int
+				k.SetInt64(int64(i + 1))
+				points[i].ScalarMultiplication(&params.Base, &k)
+				scalars[i].Mul(&s1, &k).Add(&scalars[i], &s2)
+				if i%2 == 1 {
+					scalars[i].Neg(&scalars[i])
+				}
+			}
+			// a repeated point
+			points[4] = points[0]
+
+			var expected PointProj
+			expected.setInfinity()
+			for i := range points {
+				var tmp PointProj
+				tmp.FromAffine(&points[i])
+				tmp.ScalarMultiplication(&tmp, &scalars[i])
+				expected.Add(&expected, &tmp)
+			}
+
+			var p PointProj
+			if _, err := p.MultiExp(points, scalars); err != nil {
+				return false
+			}
+			return p.Equal(&expected)
+		},
+		genS1,
+		genS2,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 
 }
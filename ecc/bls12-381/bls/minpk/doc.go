@@ -0,0 +1,19 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package minpk provides BLS signatures on the bls12-381 curve, with public keys in G1
+// and signatures in G2 (minimal-pubkey-size variant).
+//
+// The implementation follows draft-irtf-cfrg-bls-signature-05 and supports the three
+// ciphersuites of the draft: Basic, MessageAugmentation and ProofOfPossession.
+// Messages are hashed to G2 with the hash_to_curve suite G2_XMD:SHA-256_SSWU_RO_.
+//
+// PrivateKey and PublicKey implement signature.Signer and signature.PublicKey using
+// the ProofOfPossession ciphersuite, which is the one used by the Ethereum consensus layer.
+//
+// Documentation:
+// - draft-irtf-cfrg-bls-signature: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+// - RFC 9380 (hashing to elliptic curves): https://www.rfc-editor.org/rfc/rfc9380
+package minpk
@@ -0,0 +1,252 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minsig
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLS(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] test the signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-381] test the signing and verification (pre-hashed)", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+			wrong, _ := publicKey.Verify(sig, []byte("testing BLS."), nil)
+
+			return flag && !wrong
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestCiphersuites(t *testing.T) {
+	assert := require.New(t)
+
+	const nbSigners = 4
+	privKeys := make([]*PrivateKey, nbSigners)
+	pubKeys := make([]PublicKey, nbSigners)
+	for i := range privKeys {
+		var err error
+		privKeys[i], err = GenerateKey(rand.Reader)
+		assert.NoError(err)
+		pubKeys[i] = privKeys[i].PublicKey
+	}
+	msg := []byte("testing BLS")
+	messages := make([][]byte, nbSigners)
+	for i := range messages {
+		messages[i] = []byte{byte(i)}
+	}
+
+	for _, cs := range []Ciphersuite{Basic, MessageAugmentation, ProofOfPossession} {
+		// single signature
+		sig, err := cs.Sign(privKeys[0], msg)
+		assert.NoError(err)
+		ok, err := cs.Verify(&pubKeys[0], msg, sig)
+		assert.NoError(err)
+		assert.True(ok)
+		ok, err = cs.Verify(&pubKeys[1], msg, sig)
+		assert.NoError(err)
+		assert.False(ok)
+
+		// aggregate signature on distinct messages
+		sigs := make([][]byte, nbSigners)
+		for i := range sigs {
+			sigs[i], err = cs.Sign(privKeys[i], messages[i])
+			assert.NoError(err)
+		}
+		aggregated, err := Aggregate(sigs)
+		assert.NoError(err)
+		ok, err = cs.AggregateVerify(pubKeys, messages, aggregated)
+		assert.NoError(err)
+		assert.True(ok)
+		ok, err = cs.AggregateVerify(pubKeys[1:], messages[1:], aggregated)
+		assert.NoError(err)
+		assert.False(ok)
+	}
+
+	// the signatures of the different ciphersuites are domain separated
+	sig, err := Basic.Sign(privKeys[0], msg)
+	assert.NoError(err)
+	ok, err := ProofOfPossession.Verify(&pubKeys[0], msg, sig)
+	assert.NoError(err)
+	assert.False(ok)
+
+	// Basic requires distinct messages
+	sigs := make([][]byte, 2)
+	for i := range sigs {
+		sigs[i], err = Basic.Sign(privKeys[i], msg)
+		assert.NoError(err)
+	}
+	aggregated, err := Aggregate(sigs)
+	assert.NoError(err)
+	_, err = Basic.AggregateVerify(pubKeys[:2], [][]byte{msg, msg}, aggregated)
+	assert.ErrorIs(err, ErrDuplicateMessages)
+	ok, err = MessageAugmentation.AggregateVerify(pubKeys[:2], [][]byte{msg, msg}, aggregated)
+	assert.NoError(err)
+	assert.False(ok)
+
+	// fast aggregate verify and proofs of possession
+	sigs = make([][]byte, nbSigners)
+	for i := range sigs {
+		sigs[i], err = ProofOfPossession.Sign(privKeys[i], msg)
+		assert.NoError(err)
+
+		proof, err := ProofOfPossession.PopProve(privKeys[i])
+		assert.NoError(err)
+		ok, err = ProofOfPossession.PopVerify(&pubKeys[i], proof)
+		assert.NoError(err)
+		assert.True(ok)
+		ok, err = ProofOfPossession.PopVerify(&pubKeys[(i+1)%nbSigners], proof)
+		assert.NoError(err)
+		assert.False(ok)
+	}
+	aggregated, err = Aggregate(sigs)
+	assert.NoError(err)
+	ok, err = ProofOfPossession.FastAggregateVerify(pubKeys, msg, aggregated)
+	assert.NoError(err)
+	assert.True(ok)
+	ok, err = ProofOfPossession.FastAggregateVerify(pubKeys[1:], msg, aggregated)
+	assert.NoError(err)
+	assert.False(ok)
+	_, err = ProofOfPossession.FastAggregateVerify(nil, msg, aggregated)
+	assert.ErrorIs(err, ErrEmptyAggregation)
+	_, err = Basic.FastAggregateVerify(pubKeys, msg, aggregated)
+	assert.ErrorIs(err, ErrNotSupported)
+	_, err = MessageAugmentation.PopProve(privKeys[0])
+	assert.ErrorIs(err, ErrNotSupported)
+}
+
+func TestInvalidInputs(t *testing.T) {
+	assert := require.New(t)
+
+	privKey, err := GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing BLS")
+	sig, err := privKey.Sign(msg, nil)
+	assert.NoError(err)
+
+	// the public key at infinity is rejected, also when it is aggregated
+	var infinity PublicKey
+	_, err = infinity.Verify(sig, msg, nil)
+	assert.ErrorIs(err, ErrInvalidPublicKey)
+	withInfinity := []PublicKey{privKey.PublicKey, infinity}
+	_, err = AggregatePublicKeys(withInfinity)
+	assert.ErrorIs(err, ErrInvalidPublicKey)
+	_, err = ProofOfPossession.FastAggregateVerify(withInfinity, msg, sig)
+	assert.ErrorIs(err, ErrInvalidPublicKey)
+	_, err = ProofOfPossession.AggregateVerify(withInfinity, [][]byte{msg, msg}, sig)
+	assert.ErrorIs(err, ErrInvalidPublicKey)
+
+	// the signature must be a compressed point
+	_, err = privKey.PublicKey.Verify(sig[1:], msg, nil)
+	assert.Error(err)
+
+	// nothing to aggregate
+	_, err = Aggregate(nil)
+	assert.ErrorIs(err, ErrEmptyAggregation)
+	_, err = AggregatePublicKeys(nil)
+	assert.ErrorIs(err, ErrEmptyAggregation)
+	_, err = ProofOfPossession.FastAggregateVerify(nil, msg, sig)
+	assert.ErrorIs(err, ErrEmptyAggregation)
+	for _, cs := range []Ciphersuite{Basic, MessageAugmentation, ProofOfPossession} {
+		_, err = cs.AggregateVerify(nil, nil, sig)
+		assert.ErrorIs(err, ErrEmptyAggregation)
+	}
+
+	// input keying material too short
+	_, err = KeyGen(make([]byte, 31), nil)
+	assert.ErrorIs(err, ErrShortIKM)
+}
+
+func TestKeyGen(t *testing.T) {
+	assert := require.New(t)
+
+	ikm := make([]byte, 32)
+	for i := range ikm {
+		ikm[i] = byte(i)
+	}
+	sk1, err := KeyGen(ikm, nil)
+	assert.NoError(err)
+	sk2, err := KeyGen(ikm, nil)
+	assert.NoError(err)
+	sk3, err := KeyGen(ikm, []byte("key info"))
+	assert.NoError(err)
+
+	assert.Equal(sk1.Bytes(), sk2.Bytes())
+	assert.NotEqual(sk1.Bytes(), sk3.Bytes())
+}
+
+// TestKeyGenVectors checks KeyGen against the master secret keys of the EIP-2333
+// test cases, which derive them with the KeyGen of draft-irtf-cfrg-bls-signature-04
+// and an empty key_info.
+func TestKeyGenVectors(t *testing.T) {
+	assert := require.New(t)
+
+	vectors := []struct {
+		seed, sk string
+	}{
+		{
+			seed: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			sk:   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		},
+		{
+			seed: "3141592653589793238462643383279502884197169399375105820974944592",
+			sk:   "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		},
+		{
+			seed: "0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00",
+			sk:   "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		},
+		{
+			seed: "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			sk:   "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		},
+	}
+	for _, v := range vectors {
+		seed, err := hex.DecodeString(v.seed)
+		assert.NoError(err)
+		privKey, err := KeyGen(seed, nil)
+		assert.NoError(err)
+		var expected big.Int
+		expected.SetString(v.sk, 10)
+		assert.Equal(expected.FillBytes(make([]byte, sizeFr)), privKey.scalar[:])
+	}
+}
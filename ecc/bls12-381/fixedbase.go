@@ -0,0 +1,872 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// generatorWindowSize is the width of the windows of the precomputed tables
+// used by ScalarMultiplicationBase.
+const generatorWindowSize = 6
+
+var errInvalidFixedBaseTable = errors.New("invalid fixed base table encoding")
+
+// nbPointsSingleBase returns the number of points of a table built from a
+// single base with c-bit windows: the 2^{c-1} multiples of each window, the
+// last window holding 2^{lastC(c)-1} multiples to accommodate the carry.
+func nbPointsSingleBase(c uint64) int {
+	return int(computeNbChunks(c)-1)*(1<<(c-1)) + (1 << (lastC(c) - 1))
+}
+
+// G1FixedBaseTable holds precomputed multiples of one or many fixed points of
+// G1, the bases, to speed up the scalar multiplications and
+// multi-exponentiations by these bases.
+//
+// A table built from a single base B stores [k⋅2^{jc}]B for 1 ≤ k ≤ 2^{c-1}
+// and each c-bit window j of a scalar, such that [s]B costs one addition per
+// window and no doubling.
+//
+// A table built from n > 1 bases Bᵢ stores [2^{jqc}]Bᵢ for each base and each
+// group of q consecutive windows. A multi-exponentiation then runs q passes of
+// the bucket method over the n⋅⌈nbWindows/q⌉ points of the table, instead of
+// one pass per window over the n bases, which saves most of the doublings and
+// bucket reductions of MultiExp. With q = nbWindows, the table holds the bases
+// only.
+//
+// A table is safe for concurrent use once built.
+type G1FixedBaseTable struct {
+	nbBases int
+	c       uint64 // width of the windows, in bits
+	stride  uint64 // q, number of windows between two precomputed multiples of a base
+	points  []G1Affine
+}
+
+// NewG1FixedBaseTable precomputes multiples of bases, using at most maxMemory bytes
+// to store them. The parameters of the table are chosen to minimize the cost of
+// a multi-exponentiation of len(bases) scalars, or of a scalar multiplication
+// if there is a single base.
+//
+// It returns an error if bases is empty or if maxMemory is too small to store
+// the bases.
+func NewG1FixedBaseTable(bases []G1Affine, maxMemory int) (*G1FixedBaseTable, error) {
+	if len(bases) == 0 {
+		return nil, errors.New("empty list of bases")
+	}
+	maxPoints := maxMemory / int(unsafe.Sizeof(G1Affine{}))
+	errMemory := errors.New("maxMemory is too small to store the bases")
+
+	table := &G1FixedBaseTable{nbBases: len(bases), stride: 1}
+
+	if len(bases) == 1 {
+		// a scalar multiplication costs one addition per window,
+		// we pick the widest windows which fit
+		for c := uint64(2); c <= 16; c++ {
+			// the digits of the last window are stored on 16 bits
+			if lastC(c) > 16 || nbPointsSingleBase(c) > maxPoints {
+				continue
+			}
+			if table.c == 0 || computeNbChunks(c) < computeNbChunks(table.c) {
+				table.c = c
+			}
+		}
+		if table.c == 0 {
+			return nil, errMemory
+		}
+		table.initSingleBase(&bases[0])
+		return table, nil
+	}
+
+	// approximate cost (in group operations) of a multi-exponentiation
+	// cost = q * (n⋅⌈nbWindows/q⌉ + 2^{c})
+	implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	n := len(bases)
+	min := math.MaxInt
+	for _, c := range implementedCs {
+		nbChunks := computeNbChunks(c)
+		for stride := uint64(1); stride <= nbChunks; stride++ {
+			nbPoints := n * int((nbChunks+stride-1)/stride)
+			if nbPoints > maxPoints {
+				continue
+			}
+			cost := int(stride) * (nbPoints + (1 << c))
+			if cost < min {
+				min = cost
+				table.c = c
+				table.stride = stride
+			}
+		}
+	}
+	if table.c == 0 {
+		return nil, errMemory
+	}
+	table.initMultiBase(bases)
+	return table, nil
+}
+
+// NbBases returns the number of bases of the table.
+func (t *G1FixedBaseTable) NbBases() int {
+	return t.nbBases
+}
+
+// nbStoredWindows returns the number of precomputed multiples of each base of
+// a table built from many bases.
+func (t *G1FixedBaseTable) nbStoredWindows() int {
+	return int((computeNbChunks(t.c) + t.stride - 1) / t.stride)
+}
+
+// initSingleBase computes the points of a table built from base.
+func (t *G1FixedBaseTable) initSingleBase(base *G1Affine) {
+	nbChunks := computeNbChunks(t.c)
+	points := make([]G1Jac, 0, nbPointsSingleBase(t.c))
+
+	// window = [2^{jc}]B
+	var window, multiple G1Jac
+	window.FromAffine(base)
+	for j := uint64(0); j < nbChunks; j++ {
+		nbMultiples := 1 << (t.c - 1)
+		if j == nbChunks-1 {
+			nbMultiples = 1 << (lastC(t.c) - 1)
+		}
+		multiple.Set(&window)
+		for k := 0; k < nbMultiples; k++ {
+			points = append(points, multiple)
+			multiple.AddAssign(&window)
+		}
+		for k := uint64(0); k < t.c; k++ {
+			window.DoubleAssign()
+		}
+	}
+
+	t.points = BatchJacobianToAffineG1(points)
+}
+
+// initMultiBase computes the points of a table built from bases: the
+// multiples of a base are contiguous, such that the first n' bases of the
+// table are described by a prefix of t.points.
+func (t *G1FixedBaseTable) initMultiBase(bases []G1Affine) {
+	m := t.nbStoredWindows()
+	shift := t.c * t.stride
+	t.points = make([]G1Affine, len(bases)*m)
+
+	parallel.Execute(len(bases), func(start, end int) {
+		points := make([]G1Jac, (end-start)*m)
+		for i := start; i < end; i++ {
+			multiples := points[(i-start)*m : (i-start+1)*m]
+			multiples[0].FromAffine(&bases[i])
+			for j := 1; j < m; j++ {
+				multiples[j].Set(&multiples[j-1])
+				for k := uint64(0); k < shift; k++ {
+					multiples[j].DoubleAssign()
+				}
+			}
+		}
+		copy(t.points[start*m:end*m], BatchJacobianToAffineG1(points))
+	})
+}
+
+// ScalarMultiplicationFixedBase computes and returns p = [s]B where B is the
+// first base of table. Tables built from a single base are the most efficient
+// for this purpose.
+func (p *G1Affine) ScalarMultiplicationFixedBase(table *G1FixedBaseTable, s *big.Int) *G1Affine {
+	var _p G1Jac
+	_p.ScalarMultiplicationFixedBase(table, s)
+	p.FromJacobian(&_p)
+	return p
+}
+
+// ScalarMultiplicationFixedBase computes and returns p = [s]B where B is the
+// first base of table. Tables built from a single base are the most efficient
+// for this purpose.
+func (p *G1Jac) ScalarMultiplicationFixedBase(table *G1FixedBaseTable, s *big.Int) *G1Jac {
+	var e fr.Element
+	e.SetBigInt(s)
+	if table.nbBases == 1 {
+		return p.mulFixedBase(table, &e)
+	}
+	return _innerMsmFixedBaseG1(p, table, []fr.Element{e}, ecc.MultiExpConfig{NbTasks: runtime.NumCPU()})
+}
+
+// MultiExpFixedBase computes ∑ᵢ [scalars[i]]Bᵢ where Bᵢ are the first
+// len(scalars) bases of table.
+//
+// This call returns an error if len(scalars) is larger than the number of bases
+// of table or if provided config is invalid.
+func (p *G1Affine) MultiExpFixedBase(table *G1FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpFixedBase(table, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpFixedBase computes ∑ᵢ [scalars[i]]Bᵢ where Bᵢ are the first
+// len(scalars) bases of table.
+//
+// This call returns an error if len(scalars) is larger than the number of bases
+// of table or if provided config is invalid.
+func (p *G1Jac) MultiExpFixedBase(table *G1FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Jac, error) {
+	if len(scalars) > table.nbBases {
+		return nil, errors.New("len(scalars) > number of bases in the table")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	if len(scalars) == 0 {
+		return p.Set(&g1Infinity), nil
+	}
+	if table.nbBases == 1 {
+		return p.mulFixedBase(table, &scalars[0]), nil
+	}
+	return _innerMsmFixedBaseG1(p, table, scalars, config), nil
+}
+
+// mulFixedBase computes [s]B with a table built from the single base B, adding
+// the precomputed multiple of B matching each signed digit of s.
+func (p *G1Jac) mulFixedBase(table *G1FixedBaseTable, s *fr.Element) *G1Jac {
+	digits, _ := partitionScalars([]fr.Element{*s}, table.c, 1)
+
+	var res g1JacExtended
+	res.SetInfinity()
+	offset := 0
+	for _, digit := range digits {
+		if digit != 0 {
+			// same encoding as the digits of the bucket method
+			if digit&1 == 0 {
+				res.addMixed(&table.points[offset+int(digit>>1)-1])
+			} else {
+				res.subMixed(&table.points[offset+int(digit>>1)])
+			}
+		}
+		offset += 1 << (table.c - 1)
+	}
+
+	return p.fromJacExtended(&res)
+}
+
+// _innerMsmFixedBaseG1 computes ∑ᵢ [scalars[i]]Bᵢ with a table built from
+// many bases.
+//
+// The digit of the window jq+r of scalars[i] multiplies [2^{jqc}]Bᵢ in the
+// pass r: each pass is a single window of the bucket method, split in segments
+// processed concurrently, and the passes are combined with c doublings each.
+func _innerMsmFixedBaseG1(p *G1Jac, table *G1FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) *G1Jac {
+	c, stride := table.c, int(table.stride)
+	nbChunks := int(computeNbChunks(c))
+	m := table.nbStoredWindows()
+	n := len(scalars)
+	nbPoints := n * m
+	points := table.points[:nbPoints]
+
+	digits, _ := partitionScalars(scalars, c, config.NbTasks)
+
+	// we split the passes in segments to use all the tasks, as long as the
+	// segments are larger than the buckets
+	nbSegments := config.NbTasks / stride
+	if max := nbPoints >> c; nbSegments > max {
+		nbSegments = max
+	}
+	if nbSegments < 1 {
+		nbSegments = 1
+	}
+	segmentSize := (nbPoints + nbSegments - 1) / nbSegments
+	nbSegments = (nbPoints + segmentSize - 1) / segmentSize
+
+	// we use a semaphore to limit the number of go routines running concurrently
+	// (only if nbTasks < nbCPU)
+	var sem chan struct{}
+	if config.NbTasks < runtime.NumCPU() {
+		sem = make(chan struct{}, config.NbTasks)
+		for i := 0; i < config.NbTasks; i++ {
+			sem <- struct{}{}
+		}
+		defer func() {
+			close(sem)
+		}()
+	}
+
+	chPasses := make([]chan g1JacExtended, stride)
+	for r := 0; r < stride; r++ {
+		// digits of the windows r, q+r, 2q+r... in the layout of the table
+		passDigits := make([]uint16, nbPoints)
+		for j := 0; j < m && j*stride+r < nbChunks; j++ {
+			chunkDigits := digits[(j*stride+r)*n : (j*stride+r+1)*n]
+			for i := range chunkDigits {
+				passDigits[i*m+j] = chunkDigits[i]
+			}
+		}
+
+		// the digits of the last window may need more buckets
+		cc := c
+		if (nbChunks-1)%stride == r && lastC(c) > c {
+			cc = lastC(c)
+		}
+
+		chPasses[r] = make(chan g1JacExtended, nbSegments)
+		for start := 0; start < nbPoints; start += segmentSize {
+			end := start + segmentSize
+			if end > nbPoints {
+				end = nbPoints
+			}
+			// we assume the digits to be uniformly distributed
+			stat := chunkStat{nbBucketFilled: end - start}
+			if nbBuckets := 1 << (cc - 1); stat.nbBucketFilled > nbBuckets {
+				stat.nbBucketFilled = nbBuckets
+			}
+			processChunk := getChunkProcessorG1(cc, stat)
+			go processChunk(uint64(r), chPasses[r], cc, points[start:end], passDigits[start:end], sem)
+		}
+	}
+
+	var res g1JacExtended
+	res.SetInfinity()
+	for r := stride - 1; r >= 0; r-- {
+		for k := uint64(0); k < c; k++ {
+			res.double(&res)
+		}
+		for s := 0; s < nbSegments; s++ {
+			total := <-chPasses[r]
+			res.add(&total)
+		}
+	}
+
+	return p.fromJacExtended(&res)
+}
+
+// WriteTo writes the binary representation of the table to w: the number of
+// bases, the width of the windows and the stride as big endian uint64, followed
+// by the points in uncompressed form (see RawBytes).
+func (t *G1FixedBaseTable) WriteTo(w io.Writer) (int64, error) {
+	header := [3]uint64{uint64(t.nbBases), t.c, t.stride}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return 0, err
+	}
+	n := int64(binary.Size(header))
+	for i := range t.points {
+		buf := t.points[i].RawBytes()
+		written, err := w.Write(buf[:])
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom reads a table from its binary representation in r, see WriteTo.
+// The points are checked to be in the subgroup.
+func (t *G1FixedBaseTable) ReadFrom(r io.Reader) (int64, error) {
+	return t.readFrom(r, true)
+}
+
+// UnsafeReadFrom reads a table from its binary representation in r, see
+// WriteTo, without checking that the points are in the subgroup.
+func (t *G1FixedBaseTable) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return t.readFrom(r, false)
+}
+
+func (t *G1FixedBaseTable) readFrom(r io.Reader, subGroupCheck bool) (int64, error) {
+	var header [3]uint64
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return 0, err
+	}
+	n := int64(binary.Size(header))
+
+	nbBases, c, stride := header[0], header[1], header[2]
+	if nbBases == 0 || c < 2 || c > 16 || lastC(c) > 16 || stride == 0 || stride > computeNbChunks(c) ||
+		(nbBases == 1 && stride != 1) {
+		return n, errInvalidFixedBaseTable
+	}
+	res := G1FixedBaseTable{nbBases: int(nbBases), c: c, stride: stride}
+	var nbPoints int
+	if nbBases == 1 {
+		nbPoints = nbPointsSingleBase(c)
+	} else {
+		m := uint64(res.nbStoredWindows())
+		if nbBases > uint64(math.MaxInt)/m {
+			return n, errInvalidFixedBaseTable
+		}
+		nbPoints = int(nbBases * m)
+	}
+
+	// the points are allocated as they are read, to bound the memory used by
+	// an invalid header
+	const maxPrealloc = 1 << 16
+	if nbPoints <= maxPrealloc {
+		res.points = make([]G1Affine, 0, nbPoints)
+	} else {
+		res.points = make([]G1Affine, 0, maxPrealloc)
+	}
+	var buf [SizeOfG1AffineUncompressed]byte
+	for i := 0; i < nbPoints; i++ {
+		read, err := io.ReadFull(r, buf[:])
+		n += int64(read)
+		if err != nil {
+			return n, err
+		}
+		var point G1Affine
+		_, err = point.setBytes(buf[:], subGroupCheck)
+		if err != nil {
+			return n, err
+		}
+		res.points = append(res.points, point)
+	}
+
+	*t = res
+	return n, nil
+}
+
+var (
+	g1GenTableOnce sync.Once
+	g1GenTable     *G1FixedBaseTable
+)
+
+// g1GeneratorTable returns the table of the generator of
+// G1 used by ScalarMultiplicationBase, computed on first use.
+func g1GeneratorTable() *G1FixedBaseTable {
+	g1GenTableOnce.Do(func() {
+		table := &G1FixedBaseTable{nbBases: 1, c: generatorWindowSize, stride: 1}
+		table.initSingleBase(&g1GenAff)
+		g1GenTable = table
+	})
+	return g1GenTable
+}
+
+// G2FixedBaseTable holds precomputed multiples of one or many fixed points of
+// G2, the bases, to speed up the scalar multiplications and
+// multi-exponentiations by these bases.
+//
+// A table built from a single base B stores [k⋅2^{jc}]B for 1 ≤ k ≤ 2^{c-1}
+// and each c-bit window j of a scalar, such that [s]B costs one addition per
+// window and no doubling.
+//
+// A table built from n > 1 bases Bᵢ stores [2^{jqc}]Bᵢ for each base and each
+// group of q consecutive windows. A multi-exponentiation then runs q passes of
+// the bucket method over the n⋅⌈nbWindows/q⌉ points of the table, instead of
+// one pass per window over the n bases, which saves most of the doublings and
+// bucket reductions of MultiExp. With q = nbWindows, the table holds the bases
+// only.
+//
+// A table is safe for concurrent use once built.
+type G2FixedBaseTable struct {
+	nbBases int
+	c       uint64 // width of the windows, in bits
+	stride  uint64 // q, number of windows between two precomputed multiples of a base
+	points  []G2Affine
+}
+
+// NewG2FixedBaseTable precomputes multiples of bases, using at most maxMemory bytes
+// to store them. The parameters of the table are chosen to minimize the cost of
+// a multi-exponentiation of len(bases) scalars, or of a scalar multiplication
+// if there is a single base.
+//
+// It returns an error if bases is empty or if maxMemory is too small to store
+// the bases.
+func NewG2FixedBaseTable(bases []G2Affine, maxMemory int) (*G2FixedBaseTable, error) {
+	if len(bases) == 0 {
+		return nil, errors.New("empty list of bases")
+	}
+	maxPoints := maxMemory / int(unsafe.Sizeof(G2Affine{}))
+	errMemory := errors.New("maxMemory is too small to store the bases")
+
+	table := &G2FixedBaseTable{nbBases: len(bases), stride: 1}
+
+	if len(bases) == 1 {
+		// a scalar multiplication costs one addition per window,
+		// we pick the widest windows which fit
+		for c := uint64(2); c <= 16; c++ {
+			// the digits of the last window are stored on 16 bits
+			if lastC(c) > 16 || nbPointsSingleBase(c) > maxPoints {
+				continue
+			}
+			if table.c == 0 || computeNbChunks(c) < computeNbChunks(table.c) {
+				table.c = c
+			}
+		}
+		if table.c == 0 {
+			return nil, errMemory
+		}
+		table.initSingleBase(&bases[0])
+		return table, nil
+	}
+
+	// approximate cost (in group operations) of a multi-exponentiation
+	// cost = q * (n⋅⌈nbWindows/q⌉ + 2^{c})
+	implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	n := len(bases)
+	min := math.MaxInt
+	for _, c := range implementedCs {
+		nbChunks := computeNbChunks(c)
+		for stride := uint64(1); stride <= nbChunks; stride++ {
+			nbPoints := n * int((nbChunks+stride-1)/stride)
+			if nbPoints > maxPoints {
+				continue
+			}
+			cost := int(stride) * (nbPoints + (1 << c))
+			if cost < min {
+				min = cost
+				table.c = c
+				table.stride = stride
+			}
+		}
+	}
+	if table.c == 0 {
+		return nil, errMemory
+	}
+	table.initMultiBase(bases)
+	return table, nil
+}
+
+// NbBases returns the number of bases of the table.
+func (t *G2FixedBaseTable) NbBases() int {
+	return t.nbBases
+}
+
+// nbStoredWindows returns the number of precomputed multiples of each base of
+// a table built from many bases.
+func (t *G2FixedBaseTable) nbStoredWindows() int {
+	return int((computeNbChunks(t.c) + t.stride - 1) / t.stride)
+}
+
+// initSingleBase computes the points of a table built from base.
+func (t *G2FixedBaseTable) initSingleBase(base *G2Affine) {
+	nbChunks := computeNbChunks(t.c)
+	points := make([]G2Jac, 0, nbPointsSingleBase(t.c))
+
+	// window = [2^{jc}]B
+	var window, multiple G2Jac
+	window.FromAffine(base)
+	for j := uint64(0); j < nbChunks; j++ {
+		nbMultiples := 1 << (t.c - 1)
+		if j == nbChunks-1 {
+			nbMultiples = 1 << (lastC(t.c) - 1)
+		}
+		multiple.Set(&window)
+		for k := 0; k < nbMultiples; k++ {
+			points = append(points, multiple)
+			multiple.AddAssign(&window)
+		}
+		for k := uint64(0); k < t.c; k++ {
+			window.DoubleAssign()
+		}
+	}
+
+	t.points = BatchJacobianToAffineG2(points)
+}
+
+// initMultiBase computes the points of a table built from bases: the
+// multiples of a base are contiguous, such that the first n' bases of the
+// table are described by a prefix of t.points.
+func (t *G2FixedBaseTable) initMultiBase(bases []G2Affine) {
+	m := t.nbStoredWindows()
+	shift := t.c * t.stride
+	t.points = make([]G2Affine, len(bases)*m)
+
+	parallel.Execute(len(bases), func(start, end int) {
+		points := make([]G2Jac, (end-start)*m)
+		for i := start; i < end; i++ {
+			multiples := points[(i-start)*m : (i-start+1)*m]
+			multiples[0].FromAffine(&bases[i])
+			for j := 1; j < m; j++ {
+				multiples[j].Set(&multiples[j-1])
+				for k := uint64(0); k < shift; k++ {
+					multiples[j].DoubleAssign()
+				}
+			}
+		}
+		copy(t.points[start*m:end*m], BatchJacobianToAffineG2(points))
+	})
+}
+
+// ScalarMultiplicationFixedBase computes and returns p = [s]B where B is the
+// first base of table. Tables built from a single base are the most efficient
+// for this purpose.
+func (p *G2Affine) ScalarMultiplicationFixedBase(table *G2FixedBaseTable, s *big.Int) *G2Affine {
+	var _p G2Jac
+	_p.ScalarMultiplicationFixedBase(table, s)
+	p.FromJacobian(&_p)
+	return p
+}
+
+// ScalarMultiplicationFixedBase computes and returns p = [s]B where B is the
+// first base of table. Tables built from a single base are the most efficient
+// for this purpose.
+func (p *G2Jac) ScalarMultiplicationFixedBase(table *G2FixedBaseTable, s *big.Int) *G2Jac {
+	var e fr.Element
+	e.SetBigInt(s)
+	if table.nbBases == 1 {
+		return p.mulFixedBase(table, &e)
+	}
+	return _innerMsmFixedBaseG2(p, table, []fr.Element{e}, ecc.MultiExpConfig{NbTasks: runtime.NumCPU()})
+}
+
+// MultiExpFixedBase computes ∑ᵢ [scalars[i]]Bᵢ where Bᵢ are the first
+// len(scalars) bases of table.
+//
+// This call returns an error if len(scalars) is larger than the number of bases
+// of table or if provided config is invalid.
+func (p *G2Affine) MultiExpFixedBase(table *G2FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpFixedBase(table, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpFixedBase computes ∑ᵢ [scalars[i]]Bᵢ where Bᵢ are the first
+// len(scalars) bases of table.
+//
+// This call returns an error if len(scalars) is larger than the number of bases
+// of table or if provided config is invalid.
+func (p *G2Jac) MultiExpFixedBase(table *G2FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Jac, error) {
+	if len(scalars) > table.nbBases {
+		return nil, errors.New("len(scalars) > number of bases in the table")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	if len(scalars) == 0 {
+		return p.Set(&g2Infinity), nil
+	}
+	if table.nbBases == 1 {
+		return p.mulFixedBase(table, &scalars[0]), nil
+	}
+	return _innerMsmFixedBaseG2(p, table, scalars, config), nil
+}
+
+// mulFixedBase computes [s]B with a table built from the single base B, adding
+// the precomputed multiple of B matching each signed digit of s.
+func (p *G2Jac) mulFixedBase(table *G2FixedBaseTable, s *fr.Element) *G2Jac {
+	digits, _ := partitionScalars([]fr.Element{*s}, table.c, 1)
+
+	var res g2JacExtended
+	res.SetInfinity()
+	offset := 0
+	for _, digit := range digits {
+		if digit != 0 {
+			// same encoding as the digits of the bucket method
+			if digit&1 == 0 {
+				res.addMixed(&table.points[offset+int(digit>>1)-1])
+			} else {
+				res.subMixed(&table.points[offset+int(digit>>1)])
+			}
+		}
+		offset += 1 << (table.c - 1)
+	}
+
+	return p.fromJacExtended(&res)
+}
+
+// _innerMsmFixedBaseG2 computes ∑ᵢ [scalars[i]]Bᵢ with a table built from
+// many bases.
+//
+// The digit of the window jq+r of scalars[i] multiplies [2^{jqc}]Bᵢ in the
+// pass r: each pass is a single window of the bucket method, split in segments
+// processed concurrently, and the passes are combined with c doublings each.
+func _innerMsmFixedBaseG2(p *G2Jac, table *G2FixedBaseTable, scalars []fr.Element, config ecc.MultiExpConfig) *G2Jac {
+	c, stride := table.c, int(table.stride)
+	nbChunks := int(computeNbChunks(c))
+	m := table.nbStoredWindows()
+	n := len(scalars)
+	nbPoints := n * m
+	points := table.points[:nbPoints]
+
+	digits, _ := partitionScalars(scalars, c, config.NbTasks)
+
+	// we split the passes in segments to use all the tasks, as long as the
+	// segments are larger than the buckets
+	nbSegments := config.NbTasks / stride
+	if max := nbPoints >> c; nbSegments > max {
+		nbSegments = max
+	}
+	if nbSegments < 1 {
+		nbSegments = 1
+	}
+	segmentSize := (nbPoints + nbSegments - 1) / nbSegments
+	nbSegments = (nbPoints + segmentSize - 1) / segmentSize
+
+	// we use a semaphore to limit the number of go routines running concurrently
+	// (only if nbTasks < nbCPU)
+	var sem chan struct{}
+	if config.NbTasks < runtime.NumCPU() {
+		sem = make(chan struct{}, config.NbTasks)
+		for i := 0; i < config.NbTasks; i++ {
+			sem <- struct{}{}
+		}
+		defer func() {
+			close(sem)
+		}()
+	}
+
+	chPasses := make([]chan g2JacExtended, stride)
+	for r := 0; r < stride; r++ {
+		// digits of the windows r, q+r, 2q+r... in the layout of the table
+		passDigits := make([]uint16, nbPoints)
+		for j := 0; j < m && j*stride+r < nbChunks; j++ {
+			chunkDigits := digits[(j*stride+r)*n : (j*stride+r+1)*n]
+			for i := range chunkDigits {
+				passDigits[i*m+j] = chunkDigits[i]
+			}
+		}
+
+		// the digits of the last window may need more buckets
+		cc := c
+		if (nbChunks-1)%stride == r && lastC(c) > c {
+			cc = lastC(c)
+		}
+
+		chPasses[r] = make(chan g2JacExtended, nbSegments)
+		for start := 0; start < nbPoints; start += segmentSize {
+			end := start + segmentSize
+			if end > nbPoints {
+				end = nbPoints
+			}
+			// we assume the digits to be uniformly distributed
+			stat := chunkStat{nbBucketFilled: end - start}
+			if nbBuckets := 1 << (cc - 1); stat.nbBucketFilled > nbBuckets {
+				stat.nbBucketFilled = nbBuckets
+			}
+			processChunk := getChunkProcessorG2(cc, stat)
+			go processChunk(uint64(r), chPasses[r], cc, points[start:end], passDigits[start:end], sem)
+		}
+	}
+
+	var res g2JacExtended
+	res.SetInfinity()
+	for r := stride - 1; r >= 0; r-- {
+		for k := uint64(0); k < c; k++ {
+			res.double(&res)
+		}
+		for s := 0; s < nbSegments; s++ {
+			total := <-chPasses[r]
+			res.add(&total)
+		}
+	}
+
+	return p.fromJacExtended(&res)
+}
+
+// WriteTo writes the binary representation of the table to w: the number of
+// bases, the width of the windows and the stride as big endian uint64, followed
+// by the points in uncompressed form (see RawBytes).
+func (t *G2FixedBaseTable) WriteTo(w io.Writer) (int64, error) {
+	header := [3]uint64{uint64(t.nbBases), t.c, t.stride}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return 0, err
+	}
+	n := int64(binary.Size(header))
+	for i := range t.points {
+		buf := t.points[i].RawBytes()
+		written, err := w.Write(buf[:])
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom reads a table from its binary representation in r, see WriteTo.
+// The points are checked to be in the subgroup.
+func (t *G2FixedBaseTable) ReadFrom(r io.Reader) (int64, error) {
+	return t.readFrom(r, true)
+}
+
+// UnsafeReadFrom reads a table from its binary representation in r, see
+// WriteTo, without checking that the points are in the subgroup.
+func (t *G2FixedBaseTable) UnsafeReadFrom(r io.Reader) (int64, error) {
+	return t.readFrom(r, false)
+}
+
+func (t *G2FixedBaseTable) readFrom(r io.Reader, subGroupCheck bool) (int64, error) {
+	var header [3]uint64
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return 0, err
+	}
+	n := int64(binary.Size(header))
+
+	nbBases, c, stride := header[0], header[1], header[2]
+	if nbBases == 0 || c < 2 || c > 16 || lastC(c) > 16 || stride == 0 || stride > computeNbChunks(c) ||
+		(nbBases == 1 && stride != 1) {
+		return n, errInvalidFixedBaseTable
+	}
+	res := G2FixedBaseTable{nbBases: int(nbBases), c: c, stride: stride}
+	var nbPoints int
+	if nbBases == 1 {
+		nbPoints = nbPointsSingleBase(c)
+	} else {
+		m := uint64(res.nbStoredWindows())
+		if nbBases > uint64(math.MaxInt)/m {
+			return n, errInvalidFixedBaseTable
+		}
+		nbPoints = int(nbBases * m)
+	}
+
+	// the points are allocated as they are read, to bound the memory used by
+	// an invalid header
+	const maxPrealloc = 1 << 16
+	if nbPoints <= maxPrealloc {
+		res.points = make([]G2Affine, 0, nbPoints)
+	} else {
+		res.points = make([]G2Affine, 0, maxPrealloc)
+	}
+	var buf [SizeOfG2AffineUncompressed]byte
+	for i := 0; i < nbPoints; i++ {
+		read, err := io.ReadFull(r, buf[:])
+		n += int64(read)
+		if err != nil {
+			return n, err
+		}
+		var point G2Affine
+		_, err = point.setBytes(buf[:], subGroupCheck)
+		if err != nil {
+			return n, err
+		}
+		res.points = append(res.points, point)
+	}
+
+	*t = res
+	return n, nil
+}
+
+var (
+	g2GenTableOnce sync.Once
+	g2GenTable     *G2FixedBaseTable
+)
+
+// g2GeneratorTable returns the table of the generator of
+// G2 used by ScalarMultiplicationBase, computed on first use.
+func g2GeneratorTable() *G2FixedBaseTable {
+	g2GenTableOnce.Do(func() {
+		table := &G2FixedBaseTable{nbBases: 1, c: generatorWindowSize, stride: 1}
+		table.initSingleBase(&g2GenAff)
+		g2GenTable = table
+	})
+	return g2GenTable
+}
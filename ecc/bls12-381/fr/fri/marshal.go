@@ -0,0 +1,293 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// serializationVersion is the version of the binary encoding of the proofs,
+// written as their first byte.
+const serializationVersion uint8 = 1
+
+// maxPrealloc bounds the number of items allocated from a length prefix
+// before they are read, so that a malformed input can't trigger a huge
+// allocation; longer lists grow as their items are read.
+const maxPrealloc = 1 << 12
+
+var errUnsupportedVersion = errors.New("unsupported serialization version")
+
+// WriteTo writes the binary encoding of the proof of proximity to w.
+// Byte slices and lists are prefixed by their length, as a big endian uint32,
+// and field elements are big endian.
+func (proof *ProofOfProximity) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	if err := writeVersion(w, &n); err != nil {
+		return n, err
+	}
+	if err := writeBytes(w, &n, proof.ID); err != nil {
+		return n, err
+	}
+	if err := writeUint32(w, &n, uint32(len(proof.Rounds))); err != nil {
+		return n, err
+	}
+	for i := range proof.Rounds {
+		if err := writeBytes(w, &n, proof.Rounds[i].MerkleRoot); err != nil {
+			return n, err
+		}
+		m, err := proof.Rounds[i].Proof.WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	if err := writeUint32(w, &n, uint32(len(proof.FinalPolynomial))); err != nil {
+		return n, err
+	}
+	for i := range proof.FinalPolynomial {
+		if err := writeElement(w, &n, &proof.FinalPolynomial[i]); err != nil {
+			return n, err
+		}
+	}
+	err := writeUint64(w, &n, proof.Nonce)
+	return n, err
+}
+
+// ReadFrom reads a proof of proximity written by WriteTo from r.
+func (proof *ProofOfProximity) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	if err := readVersion(r, &n); err != nil {
+		return n, err
+	}
+	var err error
+	if proof.ID, err = readBytes(r, &n); err != nil {
+		return n, err
+	}
+	nbRounds, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	proof.Rounds = make([]Round, 0, min(nbRounds, maxPrealloc))
+	for i := uint32(0); i < nbRounds; i++ {
+		var round Round
+		if round.MerkleRoot, err = readBytes(r, &n); err != nil {
+			return n, err
+		}
+		m, err := round.Proof.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		proof.Rounds = append(proof.Rounds, round)
+	}
+	finalSize, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	proof.FinalPolynomial = make([]fr.Element, 0, min(finalSize, maxPrealloc))
+	for i := uint32(0); i < finalSize; i++ {
+		var e fr.Element
+		if err = readElement(r, &n, &e); err != nil {
+			return n, err
+		}
+		proof.FinalPolynomial = append(proof.FinalPolynomial, e)
+	}
+	proof.Nonce, err = readUint64(r, &n)
+	return n, err
+}
+
+// WriteTo writes the binary encoding of the batched proof of proximity to w.
+func (proof *BatchProofOfProximity) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	if err := writeVersion(w, &n); err != nil {
+		return n, err
+	}
+	if err := writeBytes(w, &n, proof.MerkleRoot); err != nil {
+		return n, err
+	}
+	m, err := proof.Proof.WriteTo(w)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	m, err = proof.ProofOfProximity.WriteTo(w)
+	n += m
+	return n, err
+}
+
+// ReadFrom reads a batched proof of proximity written by WriteTo from r.
+func (proof *BatchProofOfProximity) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	if err := readVersion(r, &n); err != nil {
+		return n, err
+	}
+	var err error
+	if proof.MerkleRoot, err = readBytes(r, &n); err != nil {
+		return n, err
+	}
+	m, err := proof.Proof.ReadFrom(r)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	m, err = proof.ProofOfProximity.ReadFrom(r)
+	n += m
+	return n, err
+}
+
+// WriteTo writes the binary encoding of the opening proof to w.
+func (proof *OpeningProof) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	if err := writeVersion(w, &n); err != nil {
+		return n, err
+	}
+	if err := writeBytes(w, &n, proof.MerkleRoot); err != nil {
+		return n, err
+	}
+	if err := writeUint32(w, &n, uint32(len(proof.ProofSet))); err != nil {
+		return n, err
+	}
+	for i := range proof.ProofSet {
+		if err := writeBytes(w, &n, proof.ProofSet[i]); err != nil {
+			return n, err
+		}
+	}
+	if err := writeUint64(w, &n, proof.NumLeaves); err != nil {
+		return n, err
+	}
+	if err := writeUint64(w, &n, proof.Index); err != nil {
+		return n, err
+	}
+	err := writeElement(w, &n, &proof.ClaimedValue)
+	return n, err
+}
+
+// ReadFrom reads an opening proof written by WriteTo from r.
+func (proof *OpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	if err := readVersion(r, &n); err != nil {
+		return n, err
+	}
+	var err error
+	if proof.MerkleRoot, err = readBytes(r, &n); err != nil {
+		return n, err
+	}
+	proofSetLen, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	proof.ProofSet = make([][]byte, 0, min(proofSetLen, maxPrealloc))
+	for i := uint32(0); i < proofSetLen; i++ {
+		b, err := readBytes(r, &n)
+		if err != nil {
+			return n, err
+		}
+		proof.ProofSet = append(proof.ProofSet, b)
+	}
+	if proof.NumLeaves, err = readUint64(r, &n); err != nil {
+		return n, err
+	}
+	if proof.Index, err = readUint64(r, &n); err != nil {
+		return n, err
+	}
+	err = readElement(r, &n, &proof.ClaimedValue)
+	return n, err
+}
+
+func writeVersion(w io.Writer, n *int64) error {
+	m, err := w.Write([]byte{serializationVersion})
+	*n += int64(m)
+	return err
+}
+
+func writeUint32(w io.Writer, n *int64, v uint32) error {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	m, err := w.Write(buf[:])
+	*n += int64(m)
+	return err
+}
+
+func writeUint64(w io.Writer, n *int64, v uint64) error {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	m, err := w.Write(buf[:])
+	*n += int64(m)
+	return err
+}
+
+func writeBytes(w io.Writer, n *int64, b []byte) error {
+	if err := writeUint32(w, n, uint32(len(b))); err != nil {
+		return err
+	}
+	m, err := w.Write(b)
+	*n += int64(m)
+	return err
+}
+
+func writeElement(w io.Writer, n *int64, e *fr.Element) error {
+	buf := e.Bytes()
+	m, err := w.Write(buf[:])
+	*n += int64(m)
+	return err
+}
+
+func readVersion(r io.Reader, n *int64) error {
+	var buf [1]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	if err != nil {
+		return err
+	}
+	if buf[0] != serializationVersion {
+		return errUnsupportedVersion
+	}
+	return nil
+}
+
+func readUint32(r io.Reader, n *int64) (uint32, error) {
+	var buf [4]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	return binary.BigEndian.Uint32(buf[:]), err
+}
+
+func readUint64(r io.Reader, n *int64) (uint64, error) {
+	var buf [8]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	return binary.BigEndian.Uint64(buf[:]), err
+}
+
+// readBytes reads a length prefixed byte slice; empty slices are read as nil.
+// The slice grows as the bytes are read, so that its length prefix alone can't
+// trigger a huge allocation.
+func readBytes(r io.Reader, n *int64) ([]byte, error) {
+	l, err := readUint32(r, n)
+	if err != nil || l == 0 {
+		return nil, err
+	}
+	res, err := io.ReadAll(io.LimitReader(r, int64(l)))
+	*n += int64(len(res))
+	if err == nil && len(res) != int(l) {
+		err = io.ErrUnexpectedEOF
+	}
+	return res, err
+}
+
+func readElement(r io.Reader, n *int64, e *fr.Element) error {
+	var buf [fr.Bytes]byte
+	m, err := io.ReadFull(r, buf[:])
+	*n += int64(m)
+	if err != nil {
+		return err
+	}
+	return e.SetBytesCanonical(buf[:])
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"math/bits"
+)
+
+// default parameters of the IOPP. A single query is far from giving a sound
+// proof: production use should set the number of queries (see NbQueries).
+const (
+	defaultFoldingFactor = 2
+	defaultNbQueries     = 1
+)
+
+// Option sets a parameter of the IOPP. The prover and the verifier must use
+// the same options.
+type Option func(*config)
+
+type config struct {
+	blowupFactor  uint64
+	nbQueries     int
+	foldingFactor uint64
+	finalDegree   uint64
+	grindingBits  int
+}
+
+func defaultConfig() config {
+	return config{
+		blowupFactor:  rho,
+		nbQueries:     defaultNbQueries,
+		foldingFactor: defaultFoldingFactor,
+	}
+}
+
+// WithBlowupFactor sets the blowup factor ρ = size_code_word/size_polynomial.
+// It must be a power of two, at least 2. The default is 8.
+func WithBlowupFactor(blowupFactor int) Option {
+	return func(c *config) {
+		c.blowupFactor = uint64(blowupFactor)
+	}
+}
+
+// WithNbQueries sets the number of queries made by the verifier. The default
+// is 1.
+func WithNbQueries(nbQueries int) Option {
+	return func(c *config) {
+		c.nbQueries = nbQueries
+	}
+}
+
+// WithFoldingFactor sets the number of evaluations folded into one at each
+// step: 2, 4, 8 or 16. A larger factor gives fewer steps, hence fewer Merkle
+// proofs, at the cost of larger leaves. The default is 2.
+func WithFoldingFactor(foldingFactor int) Option {
+	return func(c *config) {
+		c.foldingFactor = uint64(foldingFactor)
+	}
+}
+
+// WithFinalDegree stops the folding once the folded polynomial is of degree at
+// most finalDegree, and sends its coefficients in the proof. The default is 0:
+// the polynomial is folded down to a constant.
+func WithFinalDegree(finalDegree int) Option {
+	return func(c *config) {
+		c.finalDegree = uint64(finalDegree)
+	}
+}
+
+// WithGrinding requires the prover to find a proof of work of grindingBits
+// bits before the queries are derived. Each bit of grinding adds one bit of
+// security. The default is 0.
+func WithGrinding(grindingBits int) Option {
+	return func(c *config) {
+		c.grindingBits = grindingBits
+	}
+}
+
+// NbQueries returns the number of queries needed to reach securityBits bits of
+// security, for the given blowup factor and number of grinding bits. It relies
+// on the conjecture that each query brings log₂(ρ) bits of security.
+func NbQueries(securityBits, blowupFactor, grindingBits int) int {
+	logRho := bits.Len(uint(blowupFactor)) - 1
+	if logRho < 1 {
+		panic("the blowup factor should be at least 2")
+	}
+	if securityBits <= grindingBits {
+		return 1
+	}
+	return (securityBits - grindingBits + logRho - 1) / logRho
+}
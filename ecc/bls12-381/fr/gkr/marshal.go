@@ -0,0 +1,83 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/sumcheck"
+)
+
+// serializationVersion is the version of the binary encoding of the proofs,
+// written as their first byte.
+const serializationVersion uint8 = 1
+
+// maxPrealloc bounds the number of wire proofs allocated from the length
+// prefix before they are read, so that a malformed input can't trigger a huge
+// allocation; longer proofs grow as the wire proofs are read.
+const maxPrealloc = 1 << 12
+
+var errUnsupportedVersion = errors.New("unsupported serialization version")
+
+// WriteTo writes the binary encoding of the proof to w: the number of wires
+// as a big endian uint32, followed by the sumcheck proof of each wire.
+func (p *Proof) WriteTo(w io.Writer) (int64, error) {
+	var buf [4]byte
+	buf[0] = serializationVersion
+	m, err := w.Write(buf[:1])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+
+	binary.BigEndian.PutUint32(buf[:], uint32(len(*p)))
+	m, err = w.Write(buf[:])
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	for i := range *p {
+		m64, err := (*p)[i].WriteTo(w)
+		n += m64
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadFrom reads a proof written by WriteTo from r.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var buf [4]byte
+	m, err := io.ReadFull(r, buf[:1])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	if buf[0] != serializationVersion {
+		return n, errUnsupportedVersion
+	}
+
+	m, err = io.ReadFull(r, buf[:])
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	nbWires := binary.BigEndian.Uint32(buf[:])
+	*p = make(Proof, 0, min(nbWires, maxPrealloc))
+	for i := uint32(0); i < nbWires; i++ {
+		var wireProof sumcheck.Proof
+		m64, err := wireProof.ReadFrom(r)
+		n += m64
+		if err != nil {
+			return n, err
+		}
+		*p = append(*p, wireProof)
+	}
+	return n, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/test_vector_utils"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialization(t *testing.T) {
+	c := make(Circuit, 4)
+	c[2] = Wire{
+		Gate:   Gates["mul"],
+		Inputs: []*Wire{&c[0], &c[1]},
+	}
+	c[3] = Wire{
+		Gate:   Gates["add"],
+		Inputs: []*Wire{&c[2], &c[0]},
+	}
+
+	assignment := WireAssignment{&c[0]: {two, three, four, five}, &c[1]: {one, six, two, four}}.Complete(c)
+
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(t, err)
+
+	t.Run("proof round-trip", testutils.SerializationRoundTrip(&proof))
+
+	// the length prefix larger than the input isn't allocated upfront
+	_, err = proof.ReadFrom(bytes.NewReader([]byte{serializationVersion, 0xff, 0xff, 0xff, 0xff}))
+	assert.ErrorIs(t, err, io.EOF)
+}
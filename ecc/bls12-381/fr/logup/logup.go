@@ -0,0 +1,105 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	ErrNotInTable        = errors.New("some row of the witness is not in the lookup table")
+	ErrIncompatibleSize  = errors.New("the columns of the tables are not of compatible sizes")
+	ErrNoWitness         = errors.New("at least one witness table is needed")
+	ErrMalformedProof    = errors.New("the proof is malformed")
+	ErrLogUpVerification = errors.New("logup verification failed")
+)
+
+// Multiplicities returns, for each row of t, the number of times it appears
+// in the witness tables fs. The tables are given column by column, and must
+// have the same number of columns. If a row appears several times in t, its
+// lookups are accounted to its first occurrence.
+func Multiplicities(t []fr.Vector, fs ...[]fr.Vector) (fr.Vector, error) {
+	nbRows, err := nbRowsOf(t, len(t))
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, nbRows)
+	for i := 0; i < nbRows; i++ {
+		key := rowKey(t, i)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	counts := make([]uint64, nbRows)
+	for _, f := range fs {
+		n, err := nbRowsOf(f, len(t))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			j, ok := index[rowKey(f, i)]
+			if !ok {
+				return nil, ErrNotInTable
+			}
+			counts[j]++
+		}
+	}
+
+	m := make(fr.Vector, nbRows)
+	for i := range m {
+		m[i].SetUint64(counts[i])
+	}
+	return m, nil
+}
+
+// nbRowsOf returns the number of rows of table, after checking that it has
+// nbColumns non empty columns of the same size.
+func nbRowsOf(table []fr.Vector, nbColumns int) (int, error) {
+	if nbColumns == 0 || len(table) != nbColumns || len(table[0]) == 0 {
+		return 0, ErrIncompatibleSize
+	}
+	for i := range table {
+		if len(table[i]) != len(table[0]) {
+			return 0, ErrIncompatibleSize
+		}
+	}
+	return len(table[0]), nil
+}
+
+// rowKey returns the concatenation of the entries of the i-th row of table
+func rowKey(table []fr.Vector, i int) string {
+	key := make([]byte, 0, len(table)*fr.Bytes)
+	for j := range table {
+		b := table[j][i].Bytes()
+		key = append(key, b[:]...)
+	}
+	return string(key)
+}
+
+// compress returns the column ∑ⱼ λʲ⋅table[j]
+func compress(table []fr.Vector, lambda fr.Element) fr.Vector {
+	res := make(fr.Vector, len(table[0]))
+	copy(res, table[len(table)-1])
+	for j := len(table) - 2; j >= 0; j-- {
+		for i := range res {
+			res[i].Mul(&res[i], &lambda).Add(&res[i], &table[j][i])
+		}
+	}
+	return res
+}
+
+// compressEvaluations returns ∑ⱼ λʲ⋅evaluations[j]
+func compressEvaluations(evaluations []fr.Element, lambda fr.Element) fr.Element {
+	var res fr.Element
+	for j := len(evaluations) - 1; j >= 0; j-- {
+		res.Mul(&res, &lambda).Add(&res, &evaluations[j])
+	}
+	return res
+}
@@ -0,0 +1,208 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+// lookupTables returns a table of nbColumns columns and nbRows rows, and
+// witness tables of the given sizes whose rows are rows of the table.
+func lookupTables(nbColumns, nbRows int, sizes ...int) ([]fr.Vector, [][]fr.Vector) {
+	t := make([]fr.Vector, nbColumns)
+	for j := range t {
+		t[j] = make(fr.Vector, nbRows)
+		for i := range t[j] {
+			t[j][i].SetUint64(uint64(3*i + j))
+		}
+	}
+	fs := make([][]fr.Vector, len(sizes))
+	for k := range fs {
+		fs[k] = make([]fr.Vector, nbColumns)
+		for j := range fs[k] {
+			fs[k][j] = make(fr.Vector, sizes[k])
+			for i := range fs[k][j] {
+				fs[k][j][i].Set(&t[j][(5*i+k)%nbRows])
+			}
+		}
+	}
+	return t, fs
+}
+
+func TestMultiplicities(t *testing.T) {
+
+	table := []fr.Vector{make(fr.Vector, 4), make(fr.Vector, 4)}
+	for i := 0; i < 4; i++ {
+		table[0][i].SetUint64(uint64(i))
+		table[1][i].SetUint64(uint64(i * i))
+	}
+	table[0][3].SetUint64(1)
+	table[1][3].SetUint64(1) // duplicate of the row 1
+
+	f := []fr.Vector{make(fr.Vector, 5), make(fr.Vector, 5)}
+	for i, r := range []int{1, 2, 1, 0, 1} {
+		f[0][i].Set(&table[0][r])
+		f[1][i].Set(&table[1][r])
+	}
+
+	m, err := Multiplicities(table, f, f[:1])
+	if err != ErrIncompatibleSize {
+		t.Fatal("expected ErrIncompatibleSize")
+	}
+	m, err = Multiplicities(table, f, []fr.Vector{f[0][:2], f[1][:2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, e := range []uint64{1, 4, 2, 0} {
+		var expected fr.Element
+		expected.SetUint64(e)
+		if !m[i].Equal(&expected) {
+			t.Fatalf("wrong multiplicity for row %d", i)
+		}
+	}
+
+	f[1][0].SetUint64(2)
+	if _, err = Multiplicities(table, f); err != ErrNotInTable {
+		t.Fatal("expected ErrNotInTable")
+	}
+}
+
+func TestLookup(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, fs := lookupTables(3, 8, 7, 13)
+
+	// correct proof
+	{
+		proof, err := ProveLookup(kzgSrs.Pk, table, fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = VerifyLookup(kzgSrs.Vk, proof); err != nil {
+			t.Fatal(err)
+		}
+
+		// tampered proofs
+		proof.BatchedProof.ClaimedValues[0].SetRandom()
+		if err = VerifyLookup(kzgSrs.Vk, proof); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+		proof, err = ProveLookup(kzgSrs.Pk, table, fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof.Witnesses[0][0], proof.Witnesses[1][0] = proof.Witnesses[1][0], proof.Witnesses[0][0]
+		if err = VerifyLookup(kzgSrs.Vk, proof); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+	}
+
+	// single column table, single witness
+	{
+		proof, err := ProveLookup(kzgSrs.Pk, table[:1], []fr.Vector{fs[0][0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = VerifyLookup(kzgSrs.Vk, proof); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// witness not in the table
+	{
+		fs[1][2][3].SetRandom()
+		if _, err := ProveLookup(kzgSrs.Pk, table, fs...); err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+	}
+}
+
+func TestLookupMultilinear(t *testing.T) {
+
+	table, fs := lookupTables(2, 8, 8, 8, 8)
+	binding := []byte("commitments")
+
+	// correct proof
+	{
+		proof, err := ProveLookupMultilinear(table, fs, sha256.New(), binding)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := VerifyLookupMultilinear(proof, sha256.New(), binding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// check the claims against the columns
+		m, err := Multiplicities(table, fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range table {
+			e := polynomial.MultiLin(table[j]).Evaluate(claims.Point, nil)
+			if !e.Equal(&claims.Table[j]) {
+				t.Fatal("wrong claim on the table")
+			}
+		}
+		for k := range fs {
+			for j := range fs[k] {
+				e := polynomial.MultiLin(fs[k][j]).Evaluate(claims.Point, nil)
+				if !e.Equal(&claims.Witnesses[k][j]) {
+					t.Fatal("wrong claim on a witness")
+				}
+			}
+		}
+		e := polynomial.MultiLin(m).Evaluate(claims.Point, nil)
+		if !e.Equal(&claims.Multiplicities) {
+			t.Fatal("wrong claim on the multiplicities")
+		}
+
+		// different bindings
+		if _, err = VerifyLookupMultilinear(proof, sha256.New()); err == nil {
+			t.Fatal("verification with different bindings should fail")
+		}
+
+		// tampered proofs
+		proof.WitnessesEvaluations[1][0].SetRandom()
+		if _, err = VerifyLookupMultilinear(proof, sha256.New(), binding); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+		proof.WitnessesEvaluations[1][0] = claims.Witnesses[1][0]
+		proof.Evaluations[0][2].SetRandom()
+		if _, err = VerifyLookupMultilinear(proof, sha256.New(), binding); err == nil {
+			t.Fatal("verification of a tampered proof should fail")
+		}
+	}
+
+	// single witness
+	{
+		proof, err := ProveLookupMultilinear(table, fs[:1], sha256.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = VerifyLookupMultilinear(proof, sha256.New()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// witness not in the table
+	{
+		fs[2][1][5].SetRandom()
+		if _, err := ProveLookupMultilinear(table, fs, sha256.New()); err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"hash"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/sumcheck"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ProofLookupMultilinear is a multilinear LogUp proof that the rows of the
+// witness tables are rows of the table.
+//
+// The fractions 1/(β-fₖ[i]) and -m[i]/(β-t[i]) are the leaves of a binary tree
+// of fractional sums p/q. Root holds the two children p(0), p(1), q(0), q(1)
+// of its root, whose sum must be zero. Each layer of the tree is then reduced
+// to the next one with a sumcheck, down to the leaves, whose values are
+// checked against the evaluations of the columns.
+type ProofLookupMultilinear struct {
+
+	// Root p(0), p(1), q(0), q(1) of the top layer of the tree
+	Root [4]fr.Element
+
+	// Layers sumcheck proofs reducing a claim on a layer to the next one
+	Layers []sumcheck.Proof
+
+	// Evaluations p(ρ,0), p(ρ,1), q(ρ,0), q(ρ,1) of the next layer at the
+	// point ρ where the sumcheck of each layer ends
+	Evaluations [][4]fr.Element
+
+	// Evaluations of the columns of the table, of the witnesses, and of the
+	// multiplicities at the final point
+	TableEvaluations       []fr.Element
+	WitnessesEvaluations   [][]fr.Element
+	MultiplicityEvaluation fr.Element
+}
+
+// EvaluationClaims are the claims a multilinear LogUp proof reduces to: the
+// multilinear extensions of the columns evaluate to the given values at Point.
+// They must be checked by the caller, typically against commitments.
+type EvaluationClaims struct {
+	Point          []fr.Element
+	Table          []fr.Element
+	Witnesses      [][]fr.Element
+	Multiplicities fr.Element
+}
+
+// ProveLookupMultilinear returns a proof that each row of the witness tables
+// fs is a row of the table t. The tables are given column by column, and all
+// the columns must have the same size, a power of two. bindings are bound to
+// the first challenge, they typically are commitments to the columns.
+func ProveLookupMultilinear(t []fr.Vector, fs [][]fr.Vector, hFunc hash.Hash, bindings ...[]byte) (ProofLookupMultilinear, error) {
+
+	var proof ProofLookupMultilinear
+
+	if len(fs) == 0 {
+		return proof, ErrNoWitness
+	}
+	size, err := nbRowsOf(t, len(t))
+	if err != nil {
+		return proof, err
+	}
+	if bits.OnesCount(uint(size)) != 1 {
+		return proof, ErrIncompatibleSize
+	}
+	for k := range fs {
+		n, err := nbRowsOf(fs[k], len(t))
+		if err != nil {
+			return proof, err
+		}
+		if n != size {
+			return proof, ErrIncompatibleSize
+		}
+	}
+	m, err := Multiplicities(t, fs...)
+	if err != nil {
+		return proof, err
+	}
+
+	nbBlockVars := bits.Len(uint(len(fs)))
+	nbVars := nbBlockVars + bits.TrailingZeros(uint(size))
+	transcript := fiatshamir.NewTranscript(hFunc, challengeNames(nbVars)...)
+	lambda, beta, err := deriveLambdaBeta(transcript, bindings)
+	if err != nil {
+		return proof, err
+	}
+
+	// leaves of the tree: the i-th row of the k-th block is at k⋅size + i.
+	// The blocks are the witnesses, the table, and padding.
+	p := make(polynomial.MultiLin, size<<nbBlockVars)
+	q := make(polynomial.MultiLin, size<<nbBlockVars)
+	for k := range fs {
+		f := compress(fs[k], lambda)
+		for i := range f {
+			p[k*size+i].SetOne()
+			q[k*size+i].Sub(&beta, &f[i])
+		}
+	}
+	ct := compress(t, lambda)
+	for i := range ct {
+		p[len(fs)*size+i].Neg(&m[i])
+		q[len(fs)*size+i].Sub(&beta, &ct[i])
+	}
+	for i := (len(fs) + 1) * size; i < len(q); i++ {
+		q[i].SetOne()
+	}
+
+	// layers of the tree, layers[l] has 2ˡ fractions
+	layersP := make([]polynomial.MultiLin, nbVars+1)
+	layersQ := make([]polynomial.MultiLin, nbVars+1)
+	layersP[nbVars], layersQ[nbVars] = p, q
+	for l := nbVars - 1; l >= 1; l-- {
+		layersP[l], layersQ[l] = fractionalSums(layersP[l+1], layersQ[l+1])
+	}
+
+	proof.Root = [4]fr.Element{layersP[1][0], layersP[1][1], layersQ[1][0], layersQ[1][1]}
+	tau, err := deriveTau(transcript, 0, proof.Root)
+	if err != nil {
+		return proof, err
+	}
+	point := []fr.Element{tau}
+
+	proof.Layers = make([]sumcheck.Proof, nbVars-1)
+	proof.Evaluations = make([][4]fr.Element, nbVars-1)
+	for l := 1; l < nbVars; l++ {
+		claims := newLayerClaims(point, layersP[l+1], layersQ[l+1])
+		proof.Layers[l-1], err = sumcheck.Prove(claims, fiatshamir.WithTranscript(transcript, layerPrefix(l)))
+		if err != nil {
+			return proof, err
+		}
+		proof.Evaluations[l-1] = claims.evaluations
+		if tau, err = deriveTau(transcript, l, claims.evaluations); err != nil {
+			return proof, err
+		}
+		point = append(claims.challenges, tau)
+	}
+
+	// evaluations of the columns at the final point
+	rowPoint := point[nbBlockVars:]
+	proof.TableEvaluations = make([]fr.Element, len(t))
+	for j := range t {
+		proof.TableEvaluations[j] = polynomial.MultiLin(t[j]).Evaluate(rowPoint, nil)
+	}
+	proof.WitnessesEvaluations = make([][]fr.Element, len(fs))
+	for k := range fs {
+		proof.WitnessesEvaluations[k] = make([]fr.Element, len(fs[k]))
+		for j := range fs[k] {
+			proof.WitnessesEvaluations[k][j] = polynomial.MultiLin(fs[k][j]).Evaluate(rowPoint, nil)
+		}
+	}
+	proof.MultiplicityEvaluation = polynomial.MultiLin(m).Evaluate(rowPoint, nil)
+
+	return proof, nil
+}
+
+// VerifyLookupMultilinear verifies a ProofLookupMultilinear proof. On success,
+// it returns the evaluation claims on the columns that the proof reduces to;
+// the caller must check them to complete the verification.
+func VerifyLookupMultilinear(proof ProofLookupMultilinear, hFunc hash.Hash, bindings ...[]byte) (EvaluationClaims, error) {
+
+	var res EvaluationClaims
+
+	// check the shape of the proof
+	nbWitnesses := len(proof.WitnessesEvaluations)
+	nbColumns := len(proof.TableEvaluations)
+	if nbWitnesses == 0 || nbColumns == 0 || len(proof.Evaluations) != len(proof.Layers) {
+		return res, ErrMalformedProof
+	}
+	for k := range proof.WitnessesEvaluations {
+		if len(proof.WitnessesEvaluations[k]) != nbColumns {
+			return res, ErrMalformedProof
+		}
+	}
+	nbBlockVars := bits.Len(uint(nbWitnesses))
+	nbVars := len(proof.Layers) + 1
+	if nbVars < nbBlockVars {
+		return res, ErrMalformedProof
+	}
+	for l := range proof.Layers {
+		if len(proof.Layers[l].PartialSumPolys) != l+1 {
+			return res, ErrMalformedProof
+		}
+	}
+
+	transcript := fiatshamir.NewTranscript(hFunc, challengeNames(nbVars)...)
+	lambda, beta, err := deriveLambdaBeta(transcript, bindings)
+	if err != nil {
+		return res, err
+	}
+
+	// the sum of the fractions p(0)/q(0) + p(1)/q(1) must be zero
+	var sum, den fr.Element
+	sum.Mul(&proof.Root[0], &proof.Root[3])
+	den.Mul(&proof.Root[1], &proof.Root[2])
+	sum.Add(&sum, &den)
+	den.Mul(&proof.Root[2], &proof.Root[3])
+	if !sum.IsZero() || den.IsZero() {
+		return res, ErrLogUpVerification
+	}
+
+	tau, err := deriveTau(transcript, 0, proof.Root)
+	if err != nil {
+		return res, err
+	}
+	point := []fr.Element{tau}
+	claimP, claimQ := interpolateEvaluations(proof.Root, tau)
+
+	for l := 1; l < nbVars; l++ {
+		claims := &lazyLayerClaims{
+			point:       point,
+			p:           claimP,
+			q:           claimQ,
+			evaluations: proof.Evaluations[l-1],
+		}
+		if err = sumcheck.Verify(claims, proof.Layers[l-1], fiatshamir.WithTranscript(transcript, layerPrefix(l))); err != nil {
+			return res, err
+		}
+		if tau, err = deriveTau(transcript, l, proof.Evaluations[l-1]); err != nil {
+			return res, err
+		}
+		point = append(claims.challenges, tau)
+		claimP, claimQ = interpolateEvaluations(proof.Evaluations[l-1], tau)
+	}
+
+	// the leaves of the tree, at the final point, are
+	// p = ∑ₖ eq(k)⋅1 - eq(K)⋅m and q = ∑ₖ eq(k)⋅(β-fₖ) + eq(K)⋅(β-t) + ∑_{k>K} eq(k)
+	eqBlocks := make(polynomial.MultiLin, 1<<nbBlockVars)
+	eqBlocks[0].SetOne()
+	eqBlocks.Eq(point[:nbBlockVars])
+
+	var p, q, c fr.Element
+	for k := range proof.WitnessesEvaluations {
+		p.Add(&p, &eqBlocks[k])
+		c = compressEvaluations(proof.WitnessesEvaluations[k], lambda)
+		c.Sub(&beta, &c).Mul(&c, &eqBlocks[k])
+		q.Add(&q, &c)
+	}
+	c.Mul(&eqBlocks[nbWitnesses], &proof.MultiplicityEvaluation)
+	p.Sub(&p, &c)
+	c = compressEvaluations(proof.TableEvaluations, lambda)
+	c.Sub(&beta, &c).Mul(&c, &eqBlocks[nbWitnesses])
+	q.Add(&q, &c)
+	for k := nbWitnesses + 1; k < len(eqBlocks); k++ {
+		q.Add(&q, &eqBlocks[k])
+	}
+	if !p.Equal(&claimP) || !q.Equal(&claimQ) {
+		return res, ErrLogUpVerification
+	}
+
+	res.Point = point[nbBlockVars:]
+	res.Table = proof.TableEvaluations
+	res.Witnesses = proof.WitnessesEvaluations
+	res.Multiplicities = proof.MultiplicityEvaluation
+	return res, nil
+}
+
+// fractionalSums returns the layer of fractions (p[2i]/q[2i] + p[2i+1]/q[2i+1])
+func fractionalSums(p, q polynomial.MultiLin) (polynomial.MultiLin, polynomial.MultiLin) {
+	resP := make(polynomial.MultiLin, len(p)/2)
+	resQ := make(polynomial.MultiLin, len(q)/2)
+	var tmp fr.Element
+	for i := range resP {
+		resP[i].Mul(&p[2*i], &q[2*i+1])
+		tmp.Mul(&p[2*i+1], &q[2*i])
+		resP[i].Add(&resP[i], &tmp)
+		resQ[i].Mul(&q[2*i], &q[2*i+1])
+	}
+	return resP, resQ
+}
+
+// interpolateEvaluations returns p(τ) and q(τ) from e = p(0), p(1), q(0), q(1)
+func interpolateEvaluations(e [4]fr.Element, tau fr.Element) (fr.Element, fr.Element) {
+	var p, q fr.Element
+	p.Sub(&e[1], &e[0]).Mul(&p, &tau).Add(&p, &e[0])
+	q.Sub(&e[3], &e[2]).Mul(&q, &tau).Add(&q, &e[2])
+	return p, q
+}
+
+// layerClaims is the claim p(r) = ∑_y eq(r,y)(p(y,0)q(y,1) + p(y,1)q(y,0)) and
+// q(r) = ∑_y eq(r,y)q(y,0)q(y,1), on the prover side.
+type layerClaims struct {
+	eq, p0, p1, q0, q1 polynomial.MultiLin
+	combinationCoeff   fr.Element
+	challenges         []fr.Element
+	evaluations        [4]fr.Element
+}
+
+func newLayerClaims(point []fr.Element, p, q polynomial.MultiLin) *layerClaims {
+	c := &layerClaims{
+		eq: make(polynomial.MultiLin, len(p)/2),
+		p0: make(polynomial.MultiLin, len(p)/2),
+		p1: make(polynomial.MultiLin, len(p)/2),
+		q0: make(polynomial.MultiLin, len(p)/2),
+		q1: make(polynomial.MultiLin, len(p)/2),
+	}
+	c.eq[0].SetOne()
+	c.eq.Eq(point)
+	for i := range c.p0 {
+		c.p0[i], c.p1[i] = p[2*i], p[2*i+1]
+		c.q0[i], c.q1[i] = q[2*i], q[2*i+1]
+	}
+	return c
+}
+
+func (c *layerClaims) ClaimsNum() int {
+	return 2
+}
+
+func (c *layerClaims) VarsNum() int {
+	return c.eq.NumVars()
+}
+
+func (c *layerClaims) Combine(a fr.Element) polynomial.Polynomial {
+	c.combinationCoeff = a
+	return c.partialSum()
+}
+
+func (c *layerClaims) Next(r fr.Element) polynomial.Polynomial {
+	c.fold(r)
+	return c.partialSum()
+}
+
+func (c *layerClaims) ProveFinalEval(r []fr.Element) interface{} {
+	c.fold(r[len(r)-1])
+	c.evaluations = [4]fr.Element{c.p0[0], c.p1[0], c.q0[0], c.q1[0]}
+	return nil
+}
+
+func (c *layerClaims) fold(r fr.Element) {
+	c.challenges = append(c.challenges, r)
+	c.eq.Fold(r)
+	c.p0.Fold(r)
+	c.p1.Fold(r)
+	c.q0.Fold(r)
+	c.q1.Fold(r)
+}
+
+// partialSum returns the evaluations at 1, 2, 3 of the sum over the remaining
+// variables of eq⋅(p0q1 + p1q0 + a⋅q0q1), as a polynomial in the first one.
+func (c *layerClaims) partialSum() polynomial.Polynomial {
+	res := make(polynomial.Polynomial, 3)
+	mid := len(c.eq) / 2
+	var eq, p0, p1, q0, q1, dEq, dP0, dP1, dQ0, dQ1, term, tmp fr.Element
+	for i := 0; i < mid; i++ {
+		eq, p0, p1, q0, q1 = c.eq[i+mid], c.p0[i+mid], c.p1[i+mid], c.q0[i+mid], c.q1[i+mid]
+		dEq.Sub(&eq, &c.eq[i])
+		dP0.Sub(&p0, &c.p0[i])
+		dP1.Sub(&p1, &c.p1[i])
+		dQ0.Sub(&q0, &c.q0[i])
+		dQ1.Sub(&q1, &c.q1[i])
+		for d := range res {
+			if d > 0 {
+				eq.Add(&eq, &dEq)
+				p0.Add(&p0, &dP0)
+				p1.Add(&p1, &dP1)
+				q0.Add(&q0, &dQ0)
+				q1.Add(&q1, &dQ1)
+			}
+			evaluateLayer(&term, &tmp, p0, p1, q0, q1, c.combinationCoeff)
+			term.Mul(&term, &eq)
+			res[d].Add(&res[d], &term)
+		}
+	}
+	return res
+}
+
+// lazyLayerClaims is the claim of layerClaims, on the verifier side.
+type lazyLayerClaims struct {
+	point       []fr.Element
+	p, q        fr.Element
+	evaluations [4]fr.Element
+	challenges  []fr.Element
+}
+
+func (c *lazyLayerClaims) ClaimsNum() int {
+	return 2
+}
+
+func (c *lazyLayerClaims) VarsNum() int {
+	return len(c.point)
+}
+
+func (c *lazyLayerClaims) CombinedSum(a fr.Element) fr.Element {
+	var res fr.Element
+	res.Mul(&c.q, &a).Add(&res, &c.p)
+	return res
+}
+
+func (c *lazyLayerClaims) Degree(int) int {
+	return 3
+}
+
+func (c *lazyLayerClaims) VerifyFinalEval(r []fr.Element, combinationCoeff fr.Element, purportedValue fr.Element, _ interface{}) error {
+	c.challenges = r
+	var expected, tmp fr.Element
+	evaluateLayer(&expected, &tmp, c.evaluations[0], c.evaluations[1], c.evaluations[2], c.evaluations[3], combinationCoeff)
+	eq := polynomial.EvalEq(c.point, r)
+	expected.Mul(&expected, &eq)
+	if !expected.Equal(&purportedValue) {
+		return ErrLogUpVerification
+	}
+	return nil
+}
+
+// evaluateLayer sets res to p0q1 + p1q0 + a⋅q0q1, using tmp as scratch space
+func evaluateLayer(res, tmp *fr.Element, p0, p1, q0, q1, a fr.Element) {
+	res.Mul(&a, &q0).Add(res, &p0).Mul(res, &q1)
+	tmp.Mul(&p1, &q0)
+	res.Add(res, tmp)
+}
+
+// challengeNames returns the names of all the challenges of a multilinear
+// proof whose tree has nbVars layers below the root.
+func challengeNames(nbVars int) []string {
+	res := []string{"lambda", "beta", "tau.0"}
+	for l := 1; l < nbVars; l++ {
+		prefix := layerPrefix(l)
+		res = append(res, prefix+"comb")
+		for i := 0; i < l; i++ {
+			res = append(res, prefix+"pSP."+strconv.Itoa(i))
+		}
+		res = append(res, "tau."+strconv.Itoa(l))
+	}
+	return res
+}
+
+func layerPrefix(l int) string {
+	return "layer." + strconv.Itoa(l) + "."
+}
+
+func deriveLambdaBeta(transcript *fiatshamir.Transcript, bindings [][]byte) (lambda, beta fr.Element, err error) {
+	for i := range bindings {
+		if err = transcript.Bind("lambda", bindings[i]); err != nil {
+			return
+		}
+	}
+	if lambda, err = computeChallenge(transcript, "lambda"); err != nil {
+		return
+	}
+	beta, err = computeChallenge(transcript, "beta")
+	return
+}
+
+// deriveTau binds the evaluations of the l-th layer, and returns the challenge
+// extending the point to the next layer.
+func deriveTau(transcript *fiatshamir.Transcript, l int, evaluations [4]fr.Element) (fr.Element, error) {
+	name := "tau." + strconv.Itoa(l)
+	for i := range evaluations {
+		b := evaluations[i].Bytes()
+		if err := transcript.Bind(name, b[:]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return computeChallenge(transcript, name)
+}
+
+func computeChallenge(transcript *fiatshamir.Transcript, name string) (fr.Element, error) {
+	var res fr.Element
+	b, err := transcript.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark-crypto/utils/testutils"
 )
 
 func TestProof(t *testing.T) {
@@ -59,6 +60,27 @@ func TestProof(t *testing.T) {
 
 }
 
+func TestSerialization(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	assert.NoError(t, err)
+
+	a := make([]fr.Element, 8)
+	b := make([]fr.Element, 8)
+	for i := 0; i < 8; i++ {
+		a[i].SetUint64(uint64(4*i + 1))
+	}
+	for i := 0; i < 8; i++ {
+		b[i].Set(&a[(5*i)%8])
+	}
+
+	proof, err := Prove(kzgSrs.Pk, a, b)
+	assert.NoError(t, err)
+
+	t.Run("proof round-trip", testutils.SerializationRoundTrip(&proof))
+	t.Run("proof raw round-trip", testutils.SerializationRoundTripRaw(&proof))
+}
+
 func BenchmarkProver(b *testing.B) {
 
 	srsSize := 1 << 15
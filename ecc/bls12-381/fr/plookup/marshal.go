@@ -0,0 +1,175 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package plookup
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// serializationVersion is the version of the binary encoding of the proofs,
+// written as their first byte.
+const serializationVersion uint8 = 1
+
+var errUnsupportedVersion = errors.New("unsupported serialization version")
+
+// WriteTo writes binary encoding of the ProofLookupVector
+func (proof *ProofLookupVector) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of the ProofLookupVector to w without point compression
+func (proof *ProofLookupVector) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, bls12381.RawEncoding())
+}
+
+func (proof *ProofLookupVector) writeTo(w io.Writer, options ...func(*bls12381.Encoder)) (int64, error) {
+	enc := bls12381.NewEncoder(w, options...)
+
+	toEncode := []interface{}{
+		serializationVersion,
+		proof.size,
+		&proof.g,
+		&proof.h1,
+		&proof.h2,
+		&proof.t,
+		&proof.z,
+		&proof.f,
+		&proof.h,
+		&proof.BatchedProof.H,
+		proof.BatchedProof.ClaimedValues,
+		&proof.BatchedProofShifted.H,
+		proof.BatchedProofShifted.ClaimedValues,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a ProofLookupVector from r, in compressed or raw form
+func (proof *ProofLookupVector) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12381.NewDecoder(r)
+
+	if err := decodeVersion(dec); err != nil {
+		return dec.BytesRead(), err
+	}
+
+	toDecode := []interface{}{
+		&proof.size,
+		&proof.g,
+		&proof.h1,
+		&proof.h2,
+		&proof.t,
+		&proof.z,
+		&proof.f,
+		&proof.h,
+		&proof.BatchedProof.H,
+		&proof.BatchedProof.ClaimedValues,
+		&proof.BatchedProofShifted.H,
+		&proof.BatchedProofShifted.ClaimedValues,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the ProofLookupTables
+func (proof *ProofLookupTables) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the ProofLookupTables to w without point compression
+func (proof *ProofLookupTables) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, true)
+}
+
+func (proof *ProofLookupTables) writeTo(w io.Writer, raw bool) (int64, error) {
+	var options []func(*bls12381.Encoder)
+	if raw {
+		options = append(options, bls12381.RawEncoding())
+	}
+	enc := bls12381.NewEncoder(w, options...)
+
+	toEncode := []interface{}{
+		serializationVersion,
+		proof.fs,
+		proof.ts,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	n := enc.BytesWritten()
+
+	// the sub-proofs are written with the same point compression
+	var m int64
+	var err error
+	if raw {
+		m, err = proof.foldedProof.WriteRawTo(w)
+	} else {
+		m, err = proof.foldedProof.WriteTo(w)
+	}
+	n += m
+	if err != nil {
+		return n, err
+	}
+	if raw {
+		m, err = proof.permutationProof.WriteRawTo(w)
+	} else {
+		m, err = proof.permutationProof.WriteTo(w)
+	}
+	return n + m, err
+}
+
+// ReadFrom decodes a ProofLookupTables from r, in compressed or raw form
+func (proof *ProofLookupTables) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12381.NewDecoder(r)
+
+	if err := decodeVersion(dec); err != nil {
+		return dec.BytesRead(), err
+	}
+
+	toDecode := []interface{}{
+		&proof.fs,
+		&proof.ts,
+		&proof.foldedProof,
+		&proof.permutationProof,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// decodeVersion reads the version byte and checks that it is supported
+func decodeVersion(dec *bls12381.Decoder) error {
+	var version uint8
+	if err := dec.Decode(&version); err != nil {
+		return err
+	}
+	if version != serializationVersion {
+		return errUnsupportedVersion
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark-crypto/utils/testutils"
 )
 
 func TestLookupVector(t *testing.T) {
@@ -108,6 +109,41 @@ func TestLookupTable(t *testing.T) {
 
 }
 
+func TestSerialization(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookupTable := make([]fr.Vector, 3)
+	fTable := make([]fr.Vector, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(fr.Vector, 8)
+		fTable[i] = make(fr.Vector, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&lookupTable[i][(4*j+1)%8])
+		}
+	}
+
+	proofVector, err := ProveLookupVector(kzgSrs.Pk, fTable[0], lookupTable[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofTables, err := ProveLookupTables(kzgSrs.Pk, fTable, lookupTable)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("vector proof round-trip", testutils.SerializationRoundTrip(&proofVector))
+	t.Run("vector proof raw round-trip", testutils.SerializationRoundTripRaw(&proofVector))
+	t.Run("tables proof round-trip", testutils.SerializationRoundTrip(&proofTables))
+	t.Run("tables proof raw round-trip", testutils.SerializationRoundTripRaw(&proofTables))
+}
+
 func BenchmarkPlookup(b *testing.B) {
 
 	srsSize := 1 << 15
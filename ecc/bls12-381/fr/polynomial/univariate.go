@@ -0,0 +1,429 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// ErrNonDistinctPoints is returned when interpolating on a set of points
+// containing duplicates.
+var ErrNonDistinctPoints = errors.New("interpolation points are not distinct")
+
+const (
+	// mulFFTThreshold is the size of the smallest operand from which Mul
+	// switches from the schoolbook method to FFT based multiplication.
+	mulFFTThreshold = 64
+
+	// divNewtonThreshold is the size of the divisor and quotient from which
+	// DivRem switches from long division to Newton iteration.
+	divNewtonThreshold = 128
+
+	// evalLeafSize is the number of points below which the subproduct tree
+	// evaluates the remainders directly with Horner's method.
+	evalLeafSize = 16
+)
+
+// Mul sets p = p1 * p2 and returns p.
+// len(p) = len(p1) + len(p2) - 1; a new slice is always allocated, so p may
+// alias p1 or p2.
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		*p = Polynomial{}
+		return p
+	}
+	if min(len(p1), len(p2)) < mulFFTThreshold {
+		*p = mulSchoolbook(p1, p2)
+	} else {
+		*p = mulFFT(p1, p2)
+	}
+	return p
+}
+
+// Derivative sets p to the formal derivative of p1 and returns p.
+// len(p) = max(len(p1) - 1, 1); p may alias p1.
+func (p *Polynomial) Derivative(p1 Polynomial) *Polynomial {
+	if len(p1) <= 1 {
+		*p = make(Polynomial, 1)
+		return p
+	}
+	res := make(Polynomial, len(p1)-1)
+	var c fr.Element
+	for i := 1; i < len(p1); i++ {
+		c.SetUint64(uint64(i))
+		res[i-1].Mul(&p1[i], &c)
+	}
+	*p = res
+	return p
+}
+
+// Compose sets p = p1(p2) and returns p.
+// len(p) = (len(p1) - 1)(len(p2) - 1) + 1; p may alias p1 or p2.
+//
+// p1 is split in halves p1 = lo + Xᵏ hi with k a power of 2, so that
+// p1(p2) = lo(p2) + p2ᵏ hi(p2), using the precomputed powers p2^(2ⁱ).
+func (p *Polynomial) Compose(p1, p2 Polynomial) *Polynomial {
+	if len(p1) == 0 {
+		*p = Polynomial{}
+		return p
+	}
+	if len(p2) == 0 {
+		p2 = make(Polynomial, 1)
+	}
+	// powers[i] = p2^(2ⁱ) for 2ⁱ < len(p1)
+	powers := make([]Polynomial, max(bits.Len(uint(len(p1)-1)), 1))
+	powers[0] = p2
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(powers[i-1], powers[i-1])
+	}
+	*p = compose(p1, powers)
+	return p
+}
+
+func compose(p1 Polynomial, powers []Polynomial) Polynomial {
+	if len(p1) == 1 {
+		return Polynomial{p1[0]}
+	}
+	i := bits.Len(uint(len(p1)-1)) - 1
+	k := 1 << i
+	lo := compose(p1[:k], powers)
+	hi := compose(p1[k:], powers)
+
+	var res Polynomial
+	res.Mul(hi, powers[i])
+	for j := range lo {
+		res[j].Add(&res[j], &lo[j])
+	}
+	return res
+}
+
+// DivRem returns the quotient q and the remainder r of the Euclidean division
+// of p by b, such that p = q * b + r and deg(r) < deg(b).
+// len(q) = max(len(p) - len(b) + 1, 1) and len(r) = max(len(b) - 1, 1), where
+// len(b) excludes the leading zero coefficients of b.
+// It panics if b is zero.
+func (p Polynomial) DivRem(b Polynomial) (q, r Polynomial) {
+	b = b.trimmed()
+	if len(b) == 0 {
+		panic("division by the zero polynomial")
+	}
+	if len(p) < len(b) {
+		q = make(Polynomial, 1)
+		r = make(Polynomial, max(len(b)-1, 1))
+		copy(r, p)
+		return
+	}
+	if len(b)-1 < divNewtonThreshold || len(p)-len(b)+1 < divNewtonThreshold {
+		return divRemLong(p, b)
+	}
+	return divRemNewton(p, b)
+}
+
+// divRemLong is the schoolbook long division; b must not have leading zeros
+// and len(p) ≥ len(b).
+func divRemLong(p, b Polynomial) (q, r Polynomial) {
+	m := len(b) - 1
+	r = p.Clone()
+	q = make(Polynomial, len(p)-m)
+
+	var lInv, c, t fr.Element
+	lInv.Inverse(&b[m])
+	for i := len(q) - 1; i >= 0; i-- {
+		c.Mul(&r[i+m], &lInv)
+		q[i] = c
+		for j := 0; j < m; j++ {
+			t.Mul(&c, &b[j])
+			r[i+j].Sub(&r[i+j], &t)
+		}
+	}
+	r = r[:max(m, 1)]
+	if m == 0 {
+		r[0].SetZero()
+	}
+	return
+}
+
+// divRemNewton divides p by b through the reversed polynomials:
+// rev(q) = rev(p) / rev(b) mod X^(deg(p) - deg(b) + 1), where the inverse of
+// rev(b) is computed with Newton iteration; b must not have leading zeros and
+// len(p) ≥ len(b).
+func divRemNewton(p, b Polynomial) (q, r Polynomial) {
+	m := len(b) - 1
+	k := len(p) - m
+
+	revP := p.reversed()[:k]
+	revB := b.reversed()
+
+	q.Mul(revP, invSeries(revB, k))
+	q = q[:k].reversed()
+
+	var qb Polynomial
+	qb.Mul(q, b)
+	r = make(Polynomial, m)
+	for i := range r {
+		r[i].Sub(&p[i], &qb[i])
+	}
+	return
+}
+
+// invSeries returns g such that f * g = 1 mod Xⁿ; f[0] must not be zero.
+func invSeries(f Polynomial, n int) Polynomial {
+	g := make(Polynomial, 1, n)
+	g[0].Inverse(&f[0])
+
+	var two fr.Element
+	two.SetUint64(2)
+
+	// g ← g (2 - f g) mod X²ᵏ
+	for k := 1; k < n; {
+		k2 := min(2*k, n)
+		var e Polynomial
+		e.Mul(f[:min(len(f), k2)], g)
+		if len(e) < k2 {
+			e = append(e, make(Polynomial, k2-len(e))...)
+		}
+		e = e[:k2]
+		for i := range e {
+			e[i].Neg(&e[i])
+		}
+		e[0].Add(&e[0], &two)
+		g.Mul(g, e)
+		g = g[:k2]
+		k = k2
+	}
+	return g
+}
+
+// SubproductTree is the binary tree of the products ∏(X - xᵢ) over halves of a
+// set of points xᵢ. It is used for fast multipoint evaluation and
+// interpolation.
+type SubproductTree struct {
+	points []fr.Element
+	nodes  []Polynomial // heap layout, the children of node i are 2i+1 and 2i+2
+}
+
+// NewSubproductTree builds the subproduct tree of points.
+// It panics if points is empty.
+func NewSubproductTree(points []fr.Element) *SubproductTree {
+	if len(points) == 0 {
+		panic("empty set of points")
+	}
+	t := &SubproductTree{
+		points: points,
+		nodes:  make([]Polynomial, 2*(1<<bits.Len(uint(len(points)-1)))-1),
+	}
+	t.build(0, 0, len(points))
+	return t
+}
+
+func (t *SubproductTree) build(node, lo, hi int) {
+	if hi-lo == 1 {
+		t.nodes[node] = make(Polynomial, 2)
+		t.nodes[node][0].Neg(&t.points[lo])
+		t.nodes[node][1].SetOne()
+		return
+	}
+	mid := (lo + hi) / 2
+	t.build(2*node+1, lo, mid)
+	t.build(2*node+2, mid, hi)
+	t.nodes[node].Mul(t.nodes[2*node+1], t.nodes[2*node+2])
+}
+
+// Vanishing returns the vanishing polynomial ∏(X - xᵢ) of the points.
+// The returned polynomial is shared with the tree and must not be modified.
+func (t *SubproductTree) Vanishing() Polynomial {
+	return t.nodes[0]
+}
+
+// Evaluate returns the evaluations of p at the points of the tree.
+func (t *SubproductTree) Evaluate(p Polynomial) []fr.Element {
+	res := make([]fr.Element, len(t.points))
+	if len(p) == 0 {
+		return res
+	}
+	t.evaluate(0, 0, len(t.points), p, res)
+	return res
+}
+
+func (t *SubproductTree) evaluate(node, lo, hi int, p Polynomial, res []fr.Element) {
+	if len(p) >= len(t.nodes[node]) {
+		_, p = p.DivRem(t.nodes[node])
+	}
+	if hi-lo <= evalLeafSize {
+		for i := lo; i < hi; i++ {
+			res[i] = p.Eval(&t.points[i])
+		}
+		return
+	}
+	mid := (lo + hi) / 2
+	t.evaluate(2*node+1, lo, mid, p, res)
+	t.evaluate(2*node+2, mid, hi, p, res)
+}
+
+// Interpolate returns the unique polynomial f of degree < len(values) such
+// that f(xᵢ) = values[i] for the points xᵢ of the tree.
+// It returns ErrNonDistinctPoints if the points are not distinct, and panics
+// if len(values) differs from the number of points.
+func (t *SubproductTree) Interpolate(values []fr.Element) (Polynomial, error) {
+	if len(values) != len(t.points) {
+		panic("number of values and points don't match")
+	}
+
+	// Lagrange weights: cᵢ = values[i] / V'(xᵢ), where V is the vanishing
+	// polynomial of the points; V'(xᵢ) = 0 iff xᵢ is a multiple root of V.
+	var dv Polynomial
+	dv.Derivative(t.Vanishing())
+	c := t.Evaluate(dv)
+	for i := range c {
+		if c[i].IsZero() {
+			return nil, ErrNonDistinctPoints
+		}
+	}
+	c = fr.BatchInvert(c)
+	for i := range c {
+		c[i].Mul(&c[i], &values[i])
+	}
+
+	return t.interpolate(0, 0, len(t.points), c), nil
+}
+
+// interpolate returns ∑ᵢ cᵢ ∏_{j ≠ i} (X - xⱼ) over the points of the node.
+func (t *SubproductTree) interpolate(node, lo, hi int, c []fr.Element) Polynomial {
+	if hi-lo == 1 {
+		return Polynomial{c[lo]}
+	}
+	mid := (lo + hi) / 2
+	left := t.interpolate(2*node+1, lo, mid, c)
+	right := t.interpolate(2*node+2, mid, hi, c)
+
+	var res, tmp Polynomial
+	res.Mul(left, t.nodes[2*node+2])
+	tmp.Mul(right, t.nodes[2*node+1])
+	for i := range res {
+		res[i].Add(&res[i], &tmp[i])
+	}
+	return res
+}
+
+// Vanishing returns the vanishing polynomial ∏(X - xᵢ) of points.
+// It returns the constant polynomial 1 if points is empty.
+func Vanishing(points []fr.Element) Polynomial {
+	if len(points) == 0 {
+		res := make(Polynomial, 1)
+		res[0].SetOne()
+		return res
+	}
+	return NewSubproductTree(points).Vanishing()
+}
+
+// Interpolate returns the unique polynomial f of degree < len(points) such
+// that f(points[i]) = values[i].
+// It returns ErrNonDistinctPoints if the points are not distinct, and panics
+// if len(values) ≠ len(points).
+func Interpolate(points, values []fr.Element) (Polynomial, error) {
+	if len(points) != len(values) {
+		panic("number of values and points don't match")
+	}
+	if len(points) == 0 {
+		return make(Polynomial, 1), nil
+	}
+	return NewSubproductTree(points).Interpolate(values)
+}
+
+// EvalMulti evaluates p at each of the points.
+func (p Polynomial) EvalMulti(points []fr.Element) []fr.Element {
+	if len(points) <= evalLeafSize || len(p) <= evalLeafSize {
+		res := make([]fr.Element, len(points))
+		if len(p) == 0 {
+			return res
+		}
+		for i := range points {
+			res[i] = p.Eval(&points[i])
+		}
+		return res
+	}
+	return NewSubproductTree(points).Evaluate(p)
+}
+
+// trimmed returns p without its leading zero coefficients.
+func (p Polynomial) trimmed() Polynomial {
+	n := len(p)
+	for n > 0 && p[n-1].IsZero() {
+		n--
+	}
+	return p[:n]
+}
+
+// reversed returns a copy of p with the coefficients in reverse order.
+func (p Polynomial) reversed() Polynomial {
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[len(p)-1-i] = p[i]
+	}
+	return res
+}
+
+func mulSchoolbook(p1, p2 Polynomial) Polynomial {
+	res := make(Polynomial, len(p1)+len(p2)-1)
+	var t fr.Element
+	for i := range p1 {
+		for j := range p2 {
+			t.Mul(&p1[i], &p2[j])
+			res[i+j].Add(&res[i+j], &t)
+		}
+	}
+	return res
+}
+
+func mulFFT(p1, p2 Polynomial) Polynomial {
+	n := len(p1) + len(p2) - 1
+	domain := getDomain(uint64(n))
+
+	a := make([]fr.Element, domain.Cardinality)
+	b := make([]fr.Element, domain.Cardinality)
+	copy(a, p1)
+	copy(b, p2)
+
+	// the evaluations are in bit reversed order, which FFTInverse in DIT
+	// expects.
+	domain.FFT(a, fft.DIF)
+	domain.FFT(b, fft.DIF)
+	for i := range a {
+		a[i].Mul(&a[i], &b[i])
+	}
+	domain.FFTInverse(a, fft.DIT)
+
+	return a[:n]
+}
+
+// maxCachedDomainLog is the log of the cardinality of the largest fft domain
+// kept by mulFFT; larger domains are built at each multiplication, whose fft
+// then dominate the cost.
+const maxCachedDomainLog = 16
+
+// domains caches the fft domains of cardinality up to 2^maxCachedDomainLog
+// used by mulFFT, indexed by the log of their cardinality.
+var domains [maxCachedDomainLog + 1]struct {
+	once   sync.Once
+	domain *fft.Domain
+}
+
+func getDomain(n uint64) *fft.Domain {
+	logN := bits.Len64(n - 1)
+	if logN > maxCachedDomainLog {
+		return fft.NewDomain(n)
+	}
+	d := &domains[logN]
+	d.once.Do(func() {
+		d.domain = fft.NewDomain(1 << logN)
+	})
+	return d.domain
+}
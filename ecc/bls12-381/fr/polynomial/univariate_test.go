@@ -0,0 +1,206 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPolynomial(n int) Polynomial {
+	p := make(Polynomial, n)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func randomPoints(n int) []fr.Element {
+	return randomPolynomial(n)
+}
+
+func TestPolynomialMul(t *testing.T) {
+	assert := require.New(t)
+
+	for _, sizes := range [][2]int{{1, 1}, {3, 7}, {64, 64}, {65, 200}, {300, 513}} {
+		p1 := randomPolynomial(sizes[0])
+		p2 := randomPolynomial(sizes[1])
+
+		var p Polynomial
+		p.Mul(p1, p2)
+		assert.Equal(mulSchoolbook(p1, p2), p, "sizes %v", sizes)
+
+		// p(x) = p1(x) p2(x)
+		var x, e fr.Element
+		x.SetRandom()
+		e.Mul(ptr(p1.Eval(&x)), ptr(p2.Eval(&x)))
+		assert.Equal(e, p.Eval(&x))
+	}
+}
+
+func TestPolynomialDivRem(t *testing.T) {
+	assert := require.New(t)
+
+	for _, sizes := range [][2]int{{1, 1}, {5, 1}, {3, 7}, {20, 5}, {300, 150}, {600, 129}} {
+		a := randomPolynomial(sizes[0])
+		b := randomPolynomial(sizes[1])
+		// leading zeros of the divisor are ignored
+		b = append(b, fr.Element{})
+
+		q, r := a.DivRem(b)
+		assert.Less(len(r), max(len(b)-1, 2))
+
+		// a = q b + r
+		var x, e fr.Element
+		x.SetRandom()
+		e.Mul(ptr(q.Eval(&x)), ptr(b.Eval(&x)))
+		e.Add(&e, ptr(r.Eval(&x)))
+		assert.Equal(a.Eval(&x), e, "sizes %v", sizes)
+
+		// long division and Newton iteration agree
+		if len(a) >= len(b)-1 {
+			b = b[:len(b)-1]
+			ql, rl := divRemLong(a, b)
+			qn, rn := divRemNewton(a, b)
+			assert.Equal(ql, qn)
+			if len(b) > 1 {
+				assert.Equal(rl, rn)
+			}
+		}
+	}
+
+	assert.Panics(func() { randomPolynomial(3).DivRem(make(Polynomial, 2)) })
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+	assert := require.New(t)
+
+	// (X³ + 2X)' = 3X² + 2
+	p := Polynomial{fr.Element{}, fr.NewElement(2), fr.Element{}, fr.NewElement(1)}
+	p.Derivative(p)
+	assert.Equal(Polynomial{fr.NewElement(2), fr.Element{}, fr.NewElement(3)}, p)
+
+	// (p1 p2)' = p1' p2 + p1 p2'
+	p1 := randomPolynomial(10)
+	p2 := randomPolynomial(7)
+	var lhs, rhs, tmp, d Polynomial
+	lhs.Mul(p1, p2)
+	lhs.Derivative(lhs)
+	rhs.Mul(*d.Derivative(p1), p2)
+	tmp.Mul(p1, *d.Derivative(p2))
+	rhs.Add(rhs, tmp)
+	assert.Equal(lhs, rhs)
+}
+
+func TestPolynomialCompose(t *testing.T) {
+	assert := require.New(t)
+
+	for _, sizes := range [][2]int{{1, 4}, {2, 3}, {7, 5}, {33, 9}} {
+		p1 := randomPolynomial(sizes[0])
+		p2 := randomPolynomial(sizes[1])
+
+		var p Polynomial
+		p.Compose(p1, p2)
+		assert.Equal((sizes[0]-1)*(sizes[1]-1)+1, len(p))
+
+		var x fr.Element
+		x.SetRandom()
+		y := p2.Eval(&x)
+		assert.Equal(p1.Eval(&y), p.Eval(&x), "sizes %v", sizes)
+	}
+}
+
+func TestVanishing(t *testing.T) {
+	assert := require.New(t)
+
+	points := randomPoints(37)
+	v := Vanishing(points)
+	assert.Equal(len(points)+1, len(v))
+	assert.True(v[len(points)].IsOne())
+	for i := range points {
+		e := v.Eval(&points[i])
+		assert.True(e.IsZero())
+	}
+
+	var x fr.Element
+	x.SetRandom()
+	e := v.Eval(&x)
+	assert.False(e.IsZero())
+}
+
+func TestPolynomialEvalMulti(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 10, 100} {
+		p := randomPolynomial(257)
+		points := randomPoints(n)
+		evals := p.EvalMulti(points)
+		for i := range points {
+			assert.Equal(p.Eval(&points[i]), evals[i])
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 17, 200} {
+		points := randomPoints(n)
+		values := randomPoints(n)
+
+		p, err := Interpolate(points, values)
+		assert.NoError(err)
+		assert.Equal(n, len(p))
+		for i := range points {
+			assert.Equal(values[i], p.Eval(&points[i]))
+		}
+
+		// interpolating the evaluations of a polynomial gives it back
+		f := randomPolynomial(n)
+		p, err = NewSubproductTree(points).Interpolate(f.EvalMulti(points))
+		assert.NoError(err)
+		assert.Equal(f, p)
+	}
+
+	points := randomPoints(5)
+	points[3] = points[1]
+	_, err := Interpolate(points, randomPoints(5))
+	assert.ErrorIs(err, ErrNonDistinctPoints)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func BenchmarkPolynomialMul(b *testing.B) {
+	p1 := randomPolynomial(1 << 12)
+	p2 := randomPolynomial(1 << 12)
+	var p Polynomial
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Mul(p1, p2)
+	}
+}
+
+func BenchmarkPolynomialDivRem(b *testing.B) {
+	p1 := randomPolynomial(1 << 13)
+	p2 := randomPolynomial(1 << 12)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p1.DivRem(p2)
+	}
+}
+
+func BenchmarkInterpolate(b *testing.B) {
+	points := randomPoints(1 << 10)
+	values := randomPoints(1 << 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Interpolate(points, values)
+	}
+}
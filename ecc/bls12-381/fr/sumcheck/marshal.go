@@ -0,0 +1,155 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial"
+)
+
+// serializationVersion is the version of the binary encoding of the proofs,
+// written as their first byte.
+const serializationVersion uint8 = 1
+
+// maxPrealloc bounds the number of items allocated from a length prefix
+// before they are read, so that a malformed input can't trigger a huge
+// allocation; longer lists grow as their items are read.
+const maxPrealloc = 1 << 12
+
+// tags of the final evaluation proof in the binary encoding
+const (
+	finalEvalProofNil uint8 = iota
+	finalEvalProofElements
+)
+
+var (
+	errUnsupportedVersion        = errors.New("unsupported serialization version")
+	errUnsupportedFinalEvalProof = errors.New("only nil and []fr.Element final evaluation proofs can be serialized")
+)
+
+// WriteTo writes the binary encoding of the proof to w: the number of partial
+// sum polynomials as a big endian uint32, the polynomials as fr.Vector, and
+// the final evaluation proof, which must be nil or a []fr.Element.
+func (p *Proof) WriteTo(w io.Writer) (int64, error) {
+	var buf [4]byte
+	buf[0] = serializationVersion
+	m, err := w.Write(buf[:1])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+
+	binary.BigEndian.PutUint32(buf[:], uint32(len(p.PartialSumPolys)))
+	m, err = w.Write(buf[:])
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	for i := range p.PartialSumPolys {
+		v := fr.Vector(p.PartialSumPolys[i])
+		m64, err := v.WriteTo(w)
+		n += m64
+		if err != nil {
+			return n, err
+		}
+	}
+
+	switch finalEvalProof := p.FinalEvalProof.(type) {
+	case nil:
+		buf[0] = finalEvalProofNil
+		m, err = w.Write(buf[:1])
+		return n + int64(m), err
+	case []fr.Element:
+		buf[0] = finalEvalProofElements
+		m, err = w.Write(buf[:1])
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+		v := fr.Vector(finalEvalProof)
+		m64, err := v.WriteTo(w)
+		return n + m64, err
+	default:
+		return n, errUnsupportedFinalEvalProof
+	}
+}
+
+// ReadFrom reads a proof written by WriteTo from r.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var buf [4]byte
+	m, err := io.ReadFull(r, buf[:1])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	if buf[0] != serializationVersion {
+		return n, errUnsupportedVersion
+	}
+
+	m, err = io.ReadFull(r, buf[:])
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	nbPolys := binary.BigEndian.Uint32(buf[:])
+	p.PartialSumPolys = make([]polynomial.Polynomial, 0, min(nbPolys, maxPrealloc))
+	for i := uint32(0); i < nbPolys; i++ {
+		v, err := readElements(r, &n)
+		if err != nil {
+			return n, err
+		}
+		p.PartialSumPolys = append(p.PartialSumPolys, polynomial.Polynomial(v))
+	}
+
+	m, err = io.ReadFull(r, buf[:1])
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	switch buf[0] {
+	case finalEvalProofNil:
+		p.FinalEvalProof = nil
+		return n, nil
+	case finalEvalProofElements:
+		v, err := readElements(r, &n)
+		p.FinalEvalProof = v
+		return n, err
+	default:
+		return n, errUnsupportedFinalEvalProof
+	}
+}
+
+// readElements reads a list of elements encoded as a fr.Vector. Unlike
+// fr.Vector.ReadFrom, it grows the list as the elements are read, so
+// that a malformed length prefix can't trigger a huge allocation.
+func readElements(r io.Reader, n *int64) ([]fr.Element, error) {
+	var lenBuf [4]byte
+	m, err := io.ReadFull(r, lenBuf[:])
+	*n += int64(m)
+	if err != nil {
+		return nil, err
+	}
+	l := binary.BigEndian.Uint32(lenBuf[:])
+	res := make([]fr.Element, 0, min(l, maxPrealloc))
+	var e fr.Element
+	buf := e.Bytes()
+	for i := uint32(0); i < l; i++ {
+		m, err = io.ReadFull(r, buf[:])
+		*n += int64(m)
+		if err != nil {
+			return nil, err
+		}
+		if err = e.SetBytesCanonical(buf[:]); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
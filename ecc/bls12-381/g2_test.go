@@ -225,6 +225,20 @@ func TestG2AffineConversions(t *testing.T) {
 		GenE2(),
 	))
 
+	properties.Property("[BLS12-381] BatchJacobianToAffineG2 and FromJacobian should output the same result", prop.ForAll(
+		func(a, b fptower.E2) bool {
+			g1 := fuzzG2Jac(&g2Gen, a)
+			g2 := fuzzG2Jac(&g2Gen, b)
+			var op1, op2 G2Affine
+			op1.FromJacobian(&g1)
+			op2.FromJacobian(&g2)
+			baseTableAff := BatchJacobianToAffineG2([]G2Jac{g1, g2})
+			return op1.Equal(&baseTableAff[0]) && op2.Equal(&baseTableAff[1])
+		},
+		GenE2(),
+		GenE2(),
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
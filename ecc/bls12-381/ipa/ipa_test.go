@@ -0,0 +1,228 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/consensys/gnark-crypto/utils/testutils"
+)
+
+// Test SRS re-used across tests of the IPA scheme
+var testSrs *SRS
+
+const srsSize = 64
+
+func init() {
+	testSrs, _ = NewSRS(srsSize, []byte("IPA_TEST"))
+}
+
+func randomPolynomial(size int) []fr.Element {
+	f := make([]fr.Element, size)
+	for i := range f {
+		f[i].SetRandom()
+	}
+	return f
+}
+
+func TestNewSRS(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := NewSRS(3, []byte("IPA_TEST"))
+	assert.ErrorIs(err, ErrSRSSize)
+	_, err = NewSRS(1, []byte("IPA_TEST"))
+	assert.ErrorIs(err, ErrSRSSize)
+
+	// the bases are transparent: anyone derives the same ones
+	srs, err := NewSRS(srsSize, []byte("IPA_TEST"))
+	assert.NoError(err)
+	assert.Equal(testSrs, srs)
+	for i := range srs.G {
+		assert.True(srs.G[i].IsOnCurve())
+		assert.False(srs.G[i].Equal(&srs.U))
+	}
+	assert.False(srs.G[0].Equal(&srs.G[1]))
+
+	other, err := NewSRS(srsSize, []byte("IPA_OTHER"))
+	assert.NoError(err)
+	assert.False(other.U.Equal(&srs.U))
+}
+
+func TestCommit(t *testing.T) {
+	assert := require.New(t)
+
+	// commitments are additively homomorphic
+	f := randomPolynomial(srsSize)
+	g := randomPolynomial(srsSize / 2)
+	h := make([]fr.Element, srsSize)
+	copy(h, f)
+	for i := range g {
+		h[i].Add(&h[i], &g[i])
+	}
+
+	cf, err := Commit(f, testSrs)
+	assert.NoError(err)
+	cg, err := Commit(g, testSrs)
+	assert.NoError(err)
+	ch, err := Commit(h, testSrs)
+	assert.NoError(err)
+	cf.Add(&cf, &cg)
+	assert.True(cf.Equal(&ch))
+
+	_, err = Commit(randomPolynomial(srsSize+1), testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(nil, testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	for _, size := range []int{1, 7, srsSize} {
+		f := randomPolynomial(size)
+		digest, err := Commit(f, testSrs)
+		assert.NoError(err)
+
+		var point fr.Element
+		point.SetRandom()
+
+		proof, err := Open(f, digest, point, sha256.New(), testSrs, []byte("data"))
+		assert.NoError(err)
+		assert.Equal(eval(f, point), proof.ClaimedValue)
+
+		assert.NoError(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("data")))
+
+		// wrong claimed value
+		{
+			wrong := proof
+			wrong.ClaimedValue.Double(&wrong.ClaimedValue)
+			assert.ErrorIs(Verify(&digest, &wrong, point, sha256.New(), testSrs, []byte("data")), ErrVerifyOpeningProof)
+		}
+
+		// wrong final scalar
+		{
+			wrong := proof
+			wrong.A.Double(&wrong.A)
+			assert.ErrorIs(Verify(&digest, &wrong, point, sha256.New(), testSrs, []byte("data")), ErrVerifyOpeningProof)
+		}
+
+		// wrong point
+		{
+			var wrongPoint fr.Element
+			wrongPoint.Double(&point)
+			assert.ErrorIs(Verify(&digest, &proof, wrongPoint, sha256.New(), testSrs, []byte("data")), ErrVerifyOpeningProof)
+		}
+
+		// wrong transcript
+		assert.ErrorIs(Verify(&digest, &proof, point, sha256.New(), testSrs, []byte("other")), ErrVerifyOpeningProof)
+
+		// truncated proof
+		{
+			wrong := proof
+			wrong.L = wrong.L[1:]
+			assert.ErrorIs(Verify(&digest, &wrong, point, sha256.New(), testSrs, []byte("data")), ErrInvalidProofSize)
+		}
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := require.New(t)
+
+	const nbProofs = 5
+	digests := make([]Digest, nbProofs)
+	proofs := make([]OpeningProof, nbProofs)
+	points := make([]fr.Element, nbProofs)
+	for i := range proofs {
+		f := randomPolynomial(srsSize - i)
+		var err error
+		digests[i], err = Commit(f, testSrs)
+		assert.NoError(err)
+		points[i].SetRandom()
+		proofs[i], err = Open(f, digests[i], points[i], sha256.New(), testSrs)
+		assert.NoError(err)
+	}
+
+	assert.NoError(BatchVerify(digests, proofs, points, sha256.New(), testSrs))
+
+	// a single wrong proof makes the batch fail
+	proofs[2].ClaimedValue.Double(&proofs[2].ClaimedValue)
+	assert.ErrorIs(BatchVerify(digests, proofs, points, sha256.New(), testSrs), ErrVerifyOpeningProof)
+	proofs[2].ClaimedValue.Halve()
+
+	// swapping the digests makes the batch fail
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.ErrorIs(BatchVerify(digests, proofs, points, sha256.New(), testSrs), ErrVerifyOpeningProof)
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.NoError(BatchVerify(digests, proofs, points, sha256.New(), testSrs))
+
+	assert.ErrorIs(BatchVerify(digests[1:], proofs, points, sha256.New(), testSrs), ErrInvalidNbDigests)
+	assert.ErrorIs(BatchVerify(nil, nil, nil, sha256.New(), testSrs), ErrZeroNbDigests)
+}
+
+func TestSerializationSRS(t *testing.T) {
+	t.Run("SRS round-trip", testutils.SerializationRoundTrip(testSrs))
+	t.Run("SRS raw round-trip", testutils.SerializationRoundTripRaw(testSrs))
+}
+
+func TestSerializationOpeningProof(t *testing.T) {
+	f := randomPolynomial(srsSize)
+	digest, err := Commit(f, testSrs)
+	require.NoError(t, err)
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(f, digest, point, sha256.New(), testSrs)
+	require.NoError(t, err)
+
+	t.Run("opening proof round-trip", testutils.SerializationRoundTrip(&proof))
+}
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(1<<10, []byte("IPA_BENCH"))
+	require.NoError(b, err)
+	f := randomPolynomial(1 << 10)
+	digest, err := Commit(f, srs)
+	require.NoError(b, err)
+	var point fr.Element
+	point.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Open(f, digest, point, sha256.New(), srs)
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const nbProofs = 8
+	srs, err := NewSRS(1<<10, []byte("IPA_BENCH"))
+	require.NoError(b, err)
+	digests := make([]Digest, nbProofs)
+	proofs := make([]OpeningProof, nbProofs)
+	points := make([]fr.Element, nbProofs)
+	for i := range proofs {
+		f := randomPolynomial(1 << 10)
+		digests[i], _ = Commit(f, srs)
+		points[i].SetRandom()
+		proofs[i], _ = Open(f, digests[i], points[i], sha256.New(), srs)
+	}
+
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for k := range proofs {
+				_ = Verify(&digests[k], &proofs[k], points[k], sha256.New(), srs)
+			}
+		}
+	})
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = BatchVerify(digests, proofs, points, sha256.New(), srs)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package kzg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/internal/hexpoint"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidTranscript = errors.New("invalid ceremony transcript")
+)
+
+// ethereumCeremonyJSON is the subset of the Ethereum KZG ceremony transcript
+// (transcript.json) needed to build an SRS.
+type ethereumCeremonyJSON struct {
+	Transcripts []ethereumTranscriptJSON `json:"transcripts"`
+}
+
+// ethereumTranscriptJSON is the transcript of one of the sub-ceremonies.
+type ethereumTranscriptJSON struct {
+	NumG1Powers int `json:"numG1Powers"`
+	NumG2Powers int `json:"numG2Powers"`
+	PowersOfTau struct {
+		G1Powers []string `json:"G1Powers"`
+		G2Powers []string `json:"G2Powers"`
+	} `json:"powersOfTau"`
+}
+
+// NewSRSFromEthereumCeremony reads the final transcript of the Ethereum KZG ceremony
+// (https://ceremony.ethereum.org) and returns the SRS of the sub-ceremony with nbG1Powers
+// powers of τ (4096, 8192, 16384 or 32768 in the published transcript).
+//
+// Points are hex encoded compressed points. The returned SRS is checked with SRS.CheckPowers.
+func NewSRSFromEthereumCeremony(r io.Reader, nbG1Powers int) (*SRS, error) {
+	var ceremony ethereumCeremonyJSON
+	if err := json.NewDecoder(r).Decode(&ceremony); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTranscript, err)
+	}
+
+	for _, transcript := range ceremony.Transcripts {
+		if transcript.NumG1Powers != nbG1Powers {
+			continue
+		}
+		g1Powers, g2Powers := transcript.PowersOfTau.G1Powers, transcript.PowersOfTau.G2Powers
+		if len(g1Powers) != transcript.NumG1Powers || len(g2Powers) != transcript.NumG2Powers {
+			return nil, fmt.Errorf("%w: inconsistent number of powers", ErrInvalidTranscript)
+		}
+		if len(g2Powers) < 2 {
+			return nil, fmt.Errorf("%w: not enough G2 powers", ErrInvalidTranscript)
+		}
+
+		var srs SRS
+		srs.Pk.G1 = make([]bls12381.G1Affine, len(g1Powers))
+		errs := make([]error, len(g1Powers))
+		parallel.Execute(len(g1Powers), func(start, end int) {
+			for i := start; i < end; i++ {
+				errs[i] = decodeHexPoint(&srs.Pk.G1[i], g1Powers[i], bls12381.SizeOfG1AffineCompressed)
+			}
+		})
+		for i := range errs {
+			if errs[i] != nil {
+				return nil, fmt.Errorf("g1 power %d: %w", i, errs[i])
+			}
+		}
+		for i := range srs.Vk.G2 {
+			if err := decodeHexPoint(&srs.Vk.G2[i], g2Powers[i], bls12381.SizeOfG2AffineCompressed); err != nil {
+				return nil, fmt.Errorf("g2 power %d: %w", i, err)
+			}
+		}
+		srs.Vk.G1 = srs.Pk.G1[0]
+
+		if err := srs.CheckPowers(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTranscript, err)
+		}
+		srs.Vk.Lines[0] = bls12381.PrecomputeLines(srs.Vk.G2[0])
+		srs.Vk.Lines[1] = bls12381.PrecomputeLines(srs.Vk.G2[1])
+
+		return &srs, nil
+	}
+
+	return nil, fmt.Errorf("%w: no transcript with %d G1 powers", ErrInvalidTranscript, nbG1Powers)
+}
+
+// decodeHexPoint decodes a hex encoded compressed point of the given size.
+func decodeHexPoint(p interface{ SetBytes([]byte) (int, error) }, s string, size int) error {
+	if err := hexpoint.Decode(p, s, size); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTranscript, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package kzg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// ethereumCeremonyTranscript encodes srs as a transcript of the Ethereum KZG ceremony,
+// with a single sub-ceremony.
+func ethereumCeremonyTranscript(t *testing.T, srs *SRS) []byte {
+	var ceremony ethereumCeremonyJSON
+	ceremony.Transcripts = make([]ethereumTranscriptJSON, 1)
+	transcript := &ceremony.Transcripts[0]
+	transcript.NumG1Powers = len(srs.Pk.G1)
+	transcript.NumG2Powers = len(srs.Vk.G2)
+	for i := range srs.Pk.G1 {
+		b := srs.Pk.G1[i].Bytes()
+		transcript.PowersOfTau.G1Powers = append(transcript.PowersOfTau.G1Powers, "0x"+hex.EncodeToString(b[:]))
+	}
+	for i := range srs.Vk.G2 {
+		b := srs.Vk.G2[i].Bytes()
+		transcript.PowersOfTau.G2Powers = append(transcript.PowersOfTau.G2Powers, "0x"+hex.EncodeToString(b[:]))
+	}
+	res, err := json.Marshal(&ceremony)
+	require.NoError(t, err)
+	return res
+}
+
+func TestNewSRSFromEthereumCeremony(t *testing.T) {
+	assert := require.New(t)
+
+	const size = 64
+	srs, err := NewSRS(size, bAlpha)
+	assert.NoError(err)
+	transcript := ethereumCeremonyTranscript(t, srs)
+
+	imported, err := NewSRSFromEthereumCeremony(bytes.NewReader(transcript), size)
+	assert.NoError(err)
+	assert.Equal(srs.Pk.G1, imported.Pk.G1)
+	assert.Equal(srs.Vk, imported.Vk)
+
+	// the imported SRS can be used to commit and open
+	f := randomPolynomial(size)
+	digest, err := Commit(f, imported.Pk)
+	assert.NoError(err)
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(f, point, imported.Pk)
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, imported.Vk))
+
+	// unknown sub-ceremony
+	_, err = NewSRSFromEthereumCeremony(bytes.NewReader(transcript), 2*size)
+	assert.ErrorIs(err, ErrInvalidTranscript)
+
+	// inconsistent powers
+	srs.Pk.G1[3] = srs.Pk.G1[2]
+	_, err = NewSRSFromEthereumCeremony(bytes.NewReader(ethereumCeremonyTranscript(t, srs)), size)
+	assert.ErrorIs(err, ErrInvalidTranscript)
+}
+
+func TestNewSRSFromEthereumCeremonyFixture(t *testing.T) {
+	assert := require.New(t)
+
+	// testdata/transcript.json is an independent encoding of the powers of τ = 5 with the
+	// layout of the published transcript (github.com/ethereum/kzg-ceremony-specs), with two
+	// sub-ceremonies of 8 and 16 G₁ powers and 3 G₂ powers each.
+	transcript, err := os.ReadFile("testdata/transcript.json")
+	assert.NoError(err)
+
+	for _, size := range []int{8, 16} {
+		srs, err := NewSRS(uint64(size), big.NewInt(5))
+		assert.NoError(err)
+		imported, err := NewSRSFromEthereumCeremony(bytes.NewReader(transcript), size)
+		assert.NoError(err)
+		assert.Equal(srs.Pk.G1, imported.Pk.G1)
+		assert.Equal(srs.Vk, imported.Vk)
+	}
+
+	_, err = NewSRSFromEthereumCeremony(bytes.NewReader(transcript[:len(transcript)/2]), 8)
+	assert.ErrorIs(err, ErrInvalidTranscript)
+}
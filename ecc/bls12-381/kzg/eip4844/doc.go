@@ -0,0 +1,17 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package eip4844 implements the polynomial commitment API of EIP-4844 (blob transactions)
+// on top of the bls12-381 KZG scheme.
+//
+// The functions follow the naming and the semantics of the consensus specifications
+// (deneb/polynomial-commitments.md) and of the c-kzg-4844 library:
+//
+//   - blobs are interpreted as polynomials in evaluation form, over the roots of unity of
+//     order FieldElementsPerBlob taken in bit-reversed order;
+//   - challenges are derived with the Fiat-Shamir transform described in the specification;
+//   - the trusted setup is the one produced by the Ethereum KZG ceremony, in Lagrange form,
+//     and can be loaded from its JSON or text representation.
+//
+// See https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md
+package eip4844
@@ -0,0 +1,384 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+const (
+	// FieldElementsPerBlob number of scalars in a blob
+	FieldElementsPerBlob = 4096
+
+	// BytesPerFieldElement size of a serialized scalar
+	BytesPerFieldElement = fr.Bytes
+
+	// BytesPerBlob size of a blob
+	BytesPerBlob = FieldElementsPerBlob * BytesPerFieldElement
+
+	// BytesPerCommitment size of a serialized commitment (compressed G1 point)
+	BytesPerCommitment = bls12381.SizeOfG1AffineCompressed
+
+	// BytesPerProof size of a serialized opening proof (compressed G1 point)
+	BytesPerProof = bls12381.SizeOfG1AffineCompressed
+
+	// primitiveRootOfUnity generator of the multiplicative group of fr used by the specification
+	primitiveRootOfUnity = 7
+)
+
+// fiatShamirProtocolDomain domain separator used to derive the evaluation challenge of a blob
+var fiatShamirProtocolDomain = []byte("FSBLOBVERIFY_V1_")
+
+var (
+	ErrInvalidScalar        = errors.New("scalar is not canonical (larger than the scalar field modulus)")
+	ErrInvalidPoint         = errors.New("invalid compressed G1 point")
+	ErrInvalidNbBlobs       = errors.New("number of blobs, commitments and proofs differ")
+	ErrInvalidTrustedSetup  = errors.New("invalid trusted setup")
+	ErrInvalidSetupG1Length = errors.New("number of G1 points in the trusted setup must be FieldElementsPerBlob")
+	ErrInvalidSetupG2Length = errors.New("number of G2 points in the trusted setup must be at least 2")
+)
+
+// Blob is a vector of FieldElementsPerBlob big-endian canonical scalars,
+// interpreted as the evaluations of a polynomial on the bit-reversed roots of unity.
+type Blob [BytesPerBlob]byte
+
+// Bytes32 serialized scalar (big-endian)
+type Bytes32 [BytesPerFieldElement]byte
+
+// KZGCommitment serialized commitment to a blob
+type KZGCommitment [BytesPerCommitment]byte
+
+// KZGProof serialized KZG opening proof
+type KZGProof [BytesPerProof]byte
+
+// Context stores the trusted setup and the precomputed domain used by the
+// EIP-4844 functions. It is safe for concurrent use once created.
+type Context struct {
+	// g1Lagrange Lagrange basis of the trusted setup, in bit-reversed order
+	g1Lagrange []bls12381.G1Affine
+
+	// vk verifying key, the G₁ generator and the first two powers of τ in G₂
+	vk kzg.VerifyingKey
+
+	// domain roots of unity of order FieldElementsPerBlob, in bit-reversed order
+	domain []fr.Element
+
+	// cardinalityInv 1/FieldElementsPerBlob
+	cardinalityInv fr.Element
+}
+
+// NewContext returns a Context built from the Lagrange form of the trusted setup.
+//
+// * g1Lagrange is [L₀(τ)]G₁, ..., [Lₙ₋₁(τ)]G₁ in natural order, as published in the trusted setup
+// * g2Monomial is [G₂, [τ]G₂, ...]; only the first two points are used
+func NewContext(g1Lagrange []bls12381.G1Affine, g2Monomial []bls12381.G2Affine) (*Context, error) {
+	if len(g1Lagrange) != FieldElementsPerBlob {
+		return nil, ErrInvalidSetupG1Length
+	}
+	if len(g2Monomial) < 2 {
+		return nil, ErrInvalidSetupG2Length
+	}
+
+	ctx := &Context{
+		g1Lagrange: make([]bls12381.G1Affine, FieldElementsPerBlob),
+		domain:     rootsOfUnityBitReversed(FieldElementsPerBlob),
+	}
+	copy(ctx.g1Lagrange, g1Lagrange)
+	bitReverse(ctx.g1Lagrange)
+	ctx.cardinalityInv.SetUint64(FieldElementsPerBlob).Inverse(&ctx.cardinalityInv)
+
+	_, _, g1Gen, _ := bls12381.Generators()
+	ctx.vk.G1 = g1Gen
+	ctx.vk.G2[0] = g2Monomial[0]
+	ctx.vk.G2[1] = g2Monomial[1]
+	ctx.vk.Lines[0] = bls12381.PrecomputeLines(ctx.vk.G2[0])
+	ctx.vk.Lines[1] = bls12381.PrecomputeLines(ctx.vk.G2[1])
+
+	return ctx, nil
+}
+
+// BlobToKZGCommitment returns the commitment to the polynomial whose evaluations are stored in blob.
+func (ctx *Context) BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	c, err := ctx.commit(p)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return c.Bytes(), nil
+}
+
+// ComputeKZGProof returns the opening proof of the polynomial stored in blob at the point z,
+// together with the evaluation y of the polynomial at z.
+func (ctx *Context) ComputeKZGProof(blob *Blob, z Bytes32) (KZGProof, Bytes32, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	var zz fr.Element
+	if err := zz.SetBytesCanonical(z[:]); err != nil {
+		return KZGProof{}, Bytes32{}, ErrInvalidScalar
+	}
+	proof, y, err := ctx.computeKZGProof(p, zz)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	return proof.Bytes(), y.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the opening proof of the polynomial stored in blob at
+// the Fiat-Shamir challenge derived from blob and commitment.
+//
+// The commitment is not recomputed: it is the caller responsibility to ensure that it
+// corresponds to blob.
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitment KZGCommitment) (KZGProof, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	if _, err := bytesToG1(commitment[:]); err != nil {
+		return KZGProof{}, err
+	}
+	z := computeChallenge(blob, &commitment)
+	proof, _, err := ctx.computeKZGProof(p, z)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return proof.Bytes(), nil
+}
+
+// VerifyKZGProof verifies that proof attests that the polynomial committed in commitment
+// evaluates to y at z. It returns nil if the proof is valid.
+func (ctx *Context) VerifyKZGProof(commitment KZGCommitment, z, y Bytes32, proof KZGProof) error {
+	c, err := bytesToG1(commitment[:])
+	if err != nil {
+		return err
+	}
+	h, err := bytesToG1(proof[:])
+	if err != nil {
+		return err
+	}
+	var zz, yy fr.Element
+	if err := zz.SetBytesCanonical(z[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	if err := yy.SetBytesCanonical(y[:]); err != nil {
+		return ErrInvalidScalar
+	}
+	openingProof := kzg.OpeningProof{H: h, ClaimedValue: yy}
+	return kzg.Verify(&c, &openingProof, zz, ctx.vk)
+}
+
+// VerifyBlobKZGProof verifies that proof is a valid opening proof of the polynomial
+// stored in blob, committed in commitment, at the Fiat-Shamir challenge. It returns
+// nil if the proof is valid.
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) error {
+	c, h, z, y, err := ctx.prepareBlobProof(blob, &commitment, &proof)
+	if err != nil {
+		return err
+	}
+	openingProof := kzg.OpeningProof{H: h, ClaimedValue: y}
+	return kzg.Verify(&c, &openingProof, z, ctx.vk)
+}
+
+// VerifyBlobKZGProofBatch verifies a list of blob proofs using a single pairing check.
+// blobs, commitments and proofs must have the same length. It returns nil if all the proofs are valid.
+func (ctx *Context) VerifyBlobKZGProofBatch(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof) error {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return ErrInvalidNbBlobs
+	}
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	digests := make([]kzg.Digest, len(blobs))
+	openingProofs := make([]kzg.OpeningProof, len(blobs))
+	points := make([]fr.Element, len(blobs))
+	errs := make([]error, len(blobs))
+	parallel.Execute(len(blobs), func(start, end int) {
+		for i := start; i < end; i++ {
+			digests[i], openingProofs[i].H, points[i], openingProofs[i].ClaimedValue, errs[i] = ctx.prepareBlobProof(&blobs[i], &commitments[i], &proofs[i])
+		}
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			return errs[i]
+		}
+	}
+
+	return kzg.BatchVerifyMultiPoints(digests, openingProofs, points, ctx.vk)
+}
+
+// prepareBlobProof deserializes the commitment and the proof, and computes the challenge z
+// and the evaluation y of the blob at z.
+func (ctx *Context) prepareBlobProof(blob *Blob, commitment *KZGCommitment, proof *KZGProof) (c, h bls12381.G1Affine, z, y fr.Element, err error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return
+	}
+	if c, err = bytesToG1(commitment[:]); err != nil {
+		return
+	}
+	if h, err = bytesToG1(proof[:]); err != nil {
+		return
+	}
+	z = computeChallenge(blob, commitment)
+	y = ctx.evaluate(p, z)
+	return
+}
+
+// commit returns ∑ᵢpᵢ[Lᵢ(τ)]G₁
+func (ctx *Context) commit(p []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	if _, err := res.MultiExp(ctx.g1Lagrange, p, ecc.MultiExpConfig{}); err != nil {
+		return bls12381.G1Affine{}, err
+	}
+	return res, nil
+}
+
+// computeKZGProof returns the commitment to (p-p(z))/(X-z), computed in evaluation form,
+// and p(z).
+func (ctx *Context) computeKZGProof(p []fr.Element, z fr.Element) (bls12381.G1Affine, fr.Element, error) {
+	y := ctx.evaluate(p, z)
+
+	// qᵢ = (pᵢ-y)/(ωᵢ-z) for ωᵢ≠z
+	q := make([]fr.Element, len(p))
+	denominators := make([]fr.Element, len(p))
+	m := -1
+	for i := range denominators {
+		denominators[i].Sub(&ctx.domain[i], &z)
+		if denominators[i].IsZero() {
+			m = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+	for i := range q {
+		if i == m {
+			continue
+		}
+		q[i].Sub(&p[i], &y).Mul(&q[i], &denominators[i])
+	}
+
+	// if z = ωₘ, qₘ = ∑_{i≠m}(pᵢ-y)ωᵢ/(z(z-ωᵢ)) = -1/z ∑_{i≠m}qᵢωᵢ
+	if m != -1 {
+		var t fr.Element
+		for i := range q {
+			if i == m {
+				continue
+			}
+			t.Mul(&q[i], &ctx.domain[i])
+			q[m].Add(&q[m], &t)
+		}
+		t.Inverse(&z)
+		q[m].Mul(&q[m], &t).Neg(&q[m])
+	}
+
+	h, err := ctx.commit(q)
+	return h, y, err
+}
+
+// evaluate returns p(z) where p is given by its evaluations on ctx.domain, using
+// the barycentric formula p(z) = (zⁿ-1)/n ∑ᵢpᵢωᵢ/(z-ωᵢ).
+func (ctx *Context) evaluate(p []fr.Element, z fr.Element) fr.Element {
+	denominators := make([]fr.Element, len(p))
+	for i := range denominators {
+		denominators[i].Sub(&z, &ctx.domain[i])
+		if denominators[i].IsZero() {
+			return p[i]
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, t fr.Element
+	for i := range p {
+		t.Mul(&p[i], &ctx.domain[i]).Mul(&t, &denominators[i])
+		res.Add(&res, &t)
+	}
+
+	var one fr.Element
+	one.SetOne()
+	t.Exp(z, big.NewInt(int64(len(p)))).Sub(&t, &one)
+	res.Mul(&res, &t).Mul(&res, &ctx.cardinalityInv)
+	return res
+}
+
+// computeChallenge returns the Fiat-Shamir challenge of a blob, as specified:
+// hash_to_bls_field(sha256(FIAT_SHAMIR_PROTOCOL_DOMAIN ‖ degree (16 bytes, big-endian) ‖ blob ‖ commitment)).
+func computeChallenge(blob *Blob, commitment *KZGCommitment) fr.Element {
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+
+	h := sha256.New()
+	h.Write(fiatShamirProtocolDomain)
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+
+	var z fr.Element
+	z.SetBytes(h.Sum(nil))
+	return z
+}
+
+// blobToPolynomial deserializes the scalars of a blob, and fails if one of them is not canonical.
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	p := make([]fr.Element, FieldElementsPerBlob)
+	for i := range p {
+		if err := p[i].SetBytesCanonical(blob[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement]); err != nil {
+			return nil, ErrInvalidScalar
+		}
+	}
+	return p, nil
+}
+
+// bytesToG1 deserializes a compressed G1 point, checking that it lies in the correct subgroup.
+// The point at infinity is accepted.
+func bytesToG1(b []byte) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	if _, err := p.SetBytes(b); err != nil {
+		return bls12381.G1Affine{}, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// rootsOfUnityBitReversed returns the roots of unity of order n, generated by
+// primitiveRootOfUnity^((r-1)/n), in bit-reversed order.
+func rootsOfUnityBitReversed(n int) []fr.Element {
+	var generator fr.Element
+	exponent := fr.Modulus()
+	exponent.Sub(exponent, big.NewInt(1)).Div(exponent, big.NewInt(int64(n)))
+	generator.SetUint64(primitiveRootOfUnity).Exp(generator, exponent)
+
+	roots := make([]fr.Element, n)
+	roots[0].SetOne()
+	for i := 1; i < n; i++ {
+		roots[i].Mul(&roots[i-1], &generator)
+	}
+
+	bitReverse(roots)
+	return roots
+}
+
+// bitReverse applies the bit-reversal permutation to v, whose length is a power of 2.
+func bitReverse[T any](v []T) {
+	n := uint64(len(v))
+	nbBits := uint64(bits.TrailingZeros64(n))
+	for i := uint64(0); i < n; i++ {
+		irev := bits.Reverse64(i) >> (64 - nbBits)
+		if irev > i {
+			v[i], v[irev] = v[irev], v[i]
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// testTau toxic waste of the test trusted setup
+const testTau = 42
+
+var (
+	testSetupOnce     sync.Once
+	testG1Lagrange    []bls12381.G1Affine
+	testG2Monomial    []bls12381.G2Affine
+	testContextShared *Context
+)
+
+// testContext returns a Context built from a trusted setup with known τ.
+func testContext(t *testing.T) *Context {
+	testSetupOnce.Do(func() {
+		var tau, tauN, one, nInv fr.Element
+		tau.SetUint64(testTau)
+		one.SetOne()
+		nInv.SetUint64(FieldElementsPerBlob).Inverse(&nInv)
+		tauN.Exp(tau, big.NewInt(FieldElementsPerBlob)).Sub(&tauN, &one).Mul(&tauN, &nInv)
+
+		// Lᵢ(τ) = ωᵢ(τⁿ-1)/(n(τ-ωᵢ))
+		domain := rootsOfUnityBitReversed(FieldElementsPerBlob)
+		scalars := make([]fr.Element, FieldElementsPerBlob)
+		for i := range scalars {
+			scalars[i].Sub(&tau, &domain[i])
+		}
+		scalars = fr.BatchInvert(scalars)
+		for i := range scalars {
+			scalars[i].Mul(&scalars[i], &domain[i]).Mul(&scalars[i], &tauN)
+		}
+		_, _, g1Gen, g2Gen := bls12381.Generators()
+		testG1Lagrange = bls12381.BatchScalarMultiplicationG1(&g1Gen, scalars)
+		// the trusted setup is published in natural order
+		bitReverse(testG1Lagrange)
+
+		testG2Monomial = make([]bls12381.G2Affine, 2)
+		testG2Monomial[0] = g2Gen
+		testG2Monomial[1].ScalarMultiplication(&g2Gen, big.NewInt(testTau))
+
+		testContextShared, _ = NewContext(testG1Lagrange, testG2Monomial)
+	})
+	require.NotNil(t, testContextShared)
+	return testContextShared
+}
+
+func randomBlob(t *testing.T) *Blob {
+	var blob Blob
+	var e fr.Element
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		_, err := e.SetRandom()
+		require.NoError(t, err)
+		b := e.Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	return &blob
+}
+
+func TestRootsOfUnity(t *testing.T) {
+	assert := require.New(t)
+
+	roots := rootsOfUnityBitReversed(FieldElementsPerBlob)
+
+	var one, minusOne fr.Element
+	one.SetOne()
+	minusOne.Neg(&one)
+	assert.True(roots[0].Equal(&one))
+	assert.True(roots[1].Equal(&minusOne))
+
+	// roots[FieldElementsPerBlob/2] is a primitive root of unity
+	var w fr.Element
+	w.Exp(roots[FieldElementsPerBlob/2], big.NewInt(FieldElementsPerBlob/2))
+	assert.True(w.Equal(&minusOne))
+
+	// all roots are distinct
+	seen := make(map[fr.Element]struct{}, len(roots))
+	for i := range roots {
+		seen[roots[i]] = struct{}{}
+	}
+	assert.Equal(FieldElementsPerBlob, len(seen))
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	// the commitment to the zero blob is the point at infinity
+	var zero Blob
+	c, err := ctx.BlobToKZGCommitment(&zero)
+	assert.NoError(err)
+	expected := make([]byte, BytesPerCommitment)
+	expected[0] = 0xc0
+	assert.Equal(expected, c[:])
+
+	// the commitment to a random blob is [p(τ)]G₁
+	blob := randomBlob(t)
+	c, err = ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+
+	p, err := blobToPolynomial(blob)
+	assert.NoError(err)
+	var tau fr.Element
+	tau.SetUint64(testTau)
+	pTau := ctx.evaluate(p, tau)
+	var bPTau big.Int
+	pTau.BigInt(&bPTau)
+	_, _, g1Gen, _ := bls12381.Generators()
+	var expectedCommitment bls12381.G1Affine
+	expectedCommitment.ScalarMultiplication(&g1Gen, &bPTau)
+	assert.Equal(expectedCommitment.Bytes(), [BytesPerCommitment]byte(c))
+
+	// non canonical scalars are rejected
+	copy(blob[BytesPerFieldElement:], fr.Modulus().FillBytes(make([]byte, BytesPerFieldElement)))
+	_, err = ctx.BlobToKZGCommitment(blob)
+	assert.ErrorIs(err, ErrInvalidScalar)
+}
+
+func TestComputeKZGProof(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	blob := randomBlob(t)
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+	p, err := blobToPolynomial(blob)
+	assert.NoError(err)
+
+	// z outside of the domain
+	var z fr.Element
+	_, err = z.SetRandom()
+	assert.NoError(err)
+	proof, y, err := ctx.ComputeKZGProof(blob, z.Bytes())
+	assert.NoError(err)
+	assert.NoError(ctx.VerifyKZGProof(commitment, z.Bytes(), y, proof))
+
+	// wrong evaluation
+	var wrongY fr.Element
+	assert.NoError(wrongY.SetBytesCanonical(y[:]))
+	wrongY.Double(&wrongY)
+	assert.Error(ctx.VerifyKZGProof(commitment, z.Bytes(), wrongY.Bytes(), proof))
+
+	// z in the domain: the evaluation is read from the blob
+	for _, i := range []int{0, 1, 1234, FieldElementsPerBlob - 1} {
+		proof, y, err = ctx.ComputeKZGProof(blob, ctx.domain[i].Bytes())
+		assert.NoError(err)
+		assert.Equal(p[i].Bytes(), [BytesPerFieldElement]byte(y))
+		assert.NoError(ctx.VerifyKZGProof(commitment, ctx.domain[i].Bytes(), y, proof))
+	}
+
+	// non canonical z
+	var nonCanonical Bytes32
+	fr.Modulus().FillBytes(nonCanonical[:])
+	_, _, err = ctx.ComputeKZGProof(blob, nonCanonical)
+	assert.ErrorIs(err, ErrInvalidScalar)
+	assert.ErrorIs(ctx.VerifyKZGProof(commitment, nonCanonical, y, proof), ErrInvalidScalar)
+
+	// invalid point
+	var invalidProof KZGProof
+	invalidProof[0] = 0x80
+	invalidProof[1] = 0x01
+	assert.ErrorIs(ctx.VerifyKZGProof(commitment, z.Bytes(), y, invalidProof), ErrInvalidPoint)
+}
+
+func TestBlobKZGProof(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	const nbBlobs = 4
+	blobs := make([]Blob, nbBlobs)
+	commitments := make([]KZGCommitment, nbBlobs)
+	proofs := make([]KZGProof, nbBlobs)
+	for i := range blobs {
+		blobs[i] = *randomBlob(t)
+		var err error
+		commitments[i], err = ctx.BlobToKZGCommitment(&blobs[i])
+		assert.NoError(err)
+		proofs[i], err = ctx.ComputeBlobKZGProof(&blobs[i], commitments[i])
+		assert.NoError(err)
+		assert.NoError(ctx.VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i]))
+	}
+
+	assert.NoError(ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs))
+	assert.NoError(ctx.VerifyBlobKZGProofBatch(nil, nil, nil))
+	assert.ErrorIs(ctx.VerifyBlobKZGProofBatch(blobs, commitments[:1], proofs), ErrInvalidNbBlobs)
+
+	// swapping two proofs must fail
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	assert.Error(ctx.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0]))
+	assert.Error(ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs))
+}
+
+func TestLoadTrustedSetup(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	var text bytes.Buffer
+	ts := trustedSetupJSON{}
+	fmt.Fprintf(&text, "%d\n%d\n", len(testG1Lagrange), len(testG2Monomial))
+	for i := range testG1Lagrange {
+		b := testG1Lagrange[i].Bytes()
+		fmt.Fprintf(&text, "%s\n", hex.EncodeToString(b[:]))
+		ts.G1Lagrange = append(ts.G1Lagrange, "0x"+hex.EncodeToString(b[:]))
+	}
+	for i := range testG2Monomial {
+		b := testG2Monomial[i].Bytes()
+		fmt.Fprintf(&text, "%s\n", hex.EncodeToString(b[:]))
+		ts.G2Monomial = append(ts.G2Monomial, "0x"+hex.EncodeToString(b[:]))
+	}
+	jsonSetup, err := json.Marshal(ts)
+	assert.NoError(err)
+
+	fromText, err := NewContextFromText(&text)
+	assert.NoError(err)
+	fromJSON, err := NewContextFromJSON(bytes.NewReader(jsonSetup))
+	assert.NoError(err)
+
+	blob := randomBlob(t)
+	expected, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+	for _, c := range []*Context{fromText, fromJSON} {
+		commitment, err := c.BlobToKZGCommitment(blob)
+		assert.NoError(err)
+		assert.Equal(expected, commitment)
+		proof, err := c.ComputeBlobKZGProof(blob, commitment)
+		assert.NoError(err)
+		assert.NoError(ctx.VerifyBlobKZGProof(blob, commitment, proof))
+	}
+
+	// truncated setup
+	_, err = NewContextFromText(bytes.NewReader(text.Bytes()[:text.Len()/2]))
+	assert.ErrorIs(err, ErrInvalidTrustedSetup)
+	ts.G1Lagrange = ts.G1Lagrange[1:]
+	jsonSetup, err = json.Marshal(ts)
+	assert.NoError(err)
+	_, err = NewContextFromJSON(bytes.NewReader(jsonSetup))
+	assert.ErrorIs(err, ErrInvalidSetupG1Length)
+}
+
+func BenchmarkBlobToKZGCommitment(b *testing.B) {
+	ctx := testContext(&testing.T{})
+	blob := randomBlob(&testing.T{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ctx.BlobToKZGCommitment(blob)
+	}
+}
+
+func BenchmarkVerifyBlobKZGProof(b *testing.B) {
+	ctx := testContext(&testing.T{})
+	blob := randomBlob(&testing.T{})
+	commitment, _ := ctx.BlobToKZGCommitment(blob)
+	proof, _ := ctx.ComputeBlobKZGProof(blob, commitment)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ctx.VerifyBlobKZGProof(blob, commitment, proof)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/internal/hexpoint"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// trustedSetupJSON JSON representation of the trusted setup, as published in the
+// consensus specifications (presets/mainnet/trusted_setups/trusted_setup_4096.json).
+type trustedSetupJSON struct {
+	G1Lagrange []string `json:"g1_lagrange"`
+	G2Monomial []string `json:"g2_monomial"`
+}
+
+// NewContextFromJSON reads a trusted setup in the JSON format of the consensus specifications
+// and returns the corresponding Context.
+//
+// Points are hex encoded (with a 0x prefix) compressed points; they are checked to be on the
+// curve and in the correct subgroup.
+func NewContextFromJSON(r io.Reader) (*Context, error) {
+	var ts trustedSetupJSON
+	if err := json.NewDecoder(r).Decode(&ts); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTrustedSetup, err)
+	}
+	return newContextFromHex(ts.G1Lagrange, ts.G2Monomial)
+}
+
+// NewContextFromText reads a trusted setup in the text format used by c-kzg-4844
+// (trusted_setup.txt) and returns the corresponding Context.
+//
+// The format is a list of whitespace separated tokens: the number of G1 points, the number of
+// G2 points, the G1 points in Lagrange form (natural order), the G2 points in monomial
+// form and optionally the G1 points in monomial form. Points are hex encoded compressed points.
+func NewContextFromText(r io.Reader) (*Context, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	next := func() (string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", fmt.Errorf("%w: %v", ErrInvalidTrustedSetup, err)
+			}
+			return "", fmt.Errorf("%w: unexpected end of input", ErrInvalidTrustedSetup)
+		}
+		return scanner.Text(), nil
+	}
+	readLength := func() (int, error) {
+		s, err := next()
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: invalid number of points %q", ErrInvalidTrustedSetup, s)
+		}
+		return n, nil
+	}
+
+	nbG1, err := readLength()
+	if err != nil {
+		return nil, err
+	}
+	nbG2, err := readLength()
+	if err != nil {
+		return nil, err
+	}
+	if nbG1 != FieldElementsPerBlob {
+		return nil, ErrInvalidSetupG1Length
+	}
+	if nbG2 < 2 {
+		return nil, ErrInvalidSetupG2Length
+	}
+
+	g1Lagrange := make([]string, nbG1)
+	for i := range g1Lagrange {
+		if g1Lagrange[i], err = next(); err != nil {
+			return nil, err
+		}
+	}
+	g2Monomial := make([]string, nbG2)
+	for i := range g2Monomial {
+		if g2Monomial[i], err = next(); err != nil {
+			return nil, err
+		}
+	}
+
+	return newContextFromHex(g1Lagrange, g2Monomial)
+}
+
+// newContextFromHex decodes the hex encoded points of a trusted setup and builds a Context.
+func newContextFromHex(g1Lagrange, g2Monomial []string) (*Context, error) {
+	if len(g1Lagrange) != FieldElementsPerBlob {
+		return nil, ErrInvalidSetupG1Length
+	}
+	if len(g2Monomial) < 2 {
+		return nil, ErrInvalidSetupG2Length
+	}
+
+	g1 := make([]bls12381.G1Affine, len(g1Lagrange))
+	errs := make([]error, len(g1Lagrange))
+	parallel.Execute(len(g1Lagrange), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = decodeHexPoint(&g1[i], g1Lagrange[i], bls12381.SizeOfG1AffineCompressed)
+		}
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("g1 point %d: %w", i, errs[i])
+		}
+	}
+
+	// only [G₂, [τ]G₂] are needed for verification
+	var g2 [2]bls12381.G2Affine
+	for i := range g2 {
+		if err := decodeHexPoint(&g2[i], g2Monomial[i], bls12381.SizeOfG2AffineCompressed); err != nil {
+			return nil, fmt.Errorf("g2 point %d: %w", i, err)
+		}
+	}
+
+	return NewContext(g1, g2[:])
+}
+
+// decodeHexPoint decodes a hex encoded compressed point of the given size.
+func decodeHexPoint(p interface{ SetBytes([]byte) (int, error) }, s string, size int) error {
+	if err := hexpoint.Decode(p, s, size); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTrustedSetup, err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// mainnetSetup is the trusted setup of the consensus specifications
+	// (presets/mainnet/trusted_setups/trusted_setup_4096.json).
+	mainnetSetup = filepath.Join("testdata", "trusted_setup_4096.json")
+
+	// specTests is the directory of the KZG test vectors of the consensus specifications
+	// (tests/general/deneb/kzg of consensus-spec-tests), laid out as
+	// <handler>/kzg-mainnet/<case>/data.yaml.
+	specTests = filepath.Join("testdata", "kzg")
+
+	mainnetOnce    sync.Once
+	mainnetShared  *Context
+	mainnetErrInit error
+)
+
+// mainnetContext returns the Context built from the mainnet trusted setup.
+func mainnetContext(t *testing.T) *Context {
+	mainnetOnce.Do(func() {
+		f, err := os.Open(mainnetSetup)
+		if err != nil {
+			mainnetErrInit = err
+			return
+		}
+		defer f.Close()
+		mainnetShared, mainnetErrInit = NewContextFromJSON(f)
+	})
+	require.NoError(t, mainnetErrInit)
+	return mainnetShared
+}
+
+func TestTrustedSetupMainnet(t *testing.T) {
+	ctx := mainnetContext(t)
+
+	// the commitment to the constant polynomial 1 is the generator
+	var blob Blob
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		blob[(i+1)*BytesPerFieldElement-1] = 1
+	}
+	commitment, err := ctx.BlobToKZGCommitment(&blob)
+	require.NoError(t, err)
+	require.Equal(t, KZGCommitment(ctx.vk.G1.Bytes()), commitment)
+}
+
+// runSpecTests decodes the cases of handler into a new value of the type of test,
+// and runs check on each of them.
+func runSpecTests[T any](t *testing.T, handler string, check func(t *testing.T, test *T)) {
+	tests, err := filepath.Glob(filepath.Join(specTests, handler, "kzg-mainnet", "*", "data.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, tests, "no test vectors for %s", handler)
+	for _, testPath := range tests {
+		t.Run(filepath.Base(filepath.Dir(testPath)), func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			test := new(T)
+			err = yaml.NewDecoder(testFile).Decode(test)
+			require.NoError(t, testFile.Close())
+			require.NoError(t, err)
+			check(t, test)
+		})
+	}
+}
+
+// decodeFixed decodes the 0x prefixed hex string s into dst. It returns false if s
+// doesn't have the size of dst.
+func decodeFixed(t *testing.T, dst []byte, s string) bool {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	require.NoError(t, err)
+	if len(b) != len(dst) {
+		return false
+	}
+	copy(dst, b)
+	return true
+}
+
+// checkVerification checks the result of a verification against the expected output:
+// true if it succeeds, false if the pairing check fails, and null for invalid inputs.
+func checkVerification(t *testing.T, err error, output *bool) {
+	switch {
+	case output == nil:
+		require.Error(t, err)
+		require.NotErrorIs(t, err, kzg.ErrVerifyOpeningProof)
+	case *output:
+		require.NoError(t, err)
+	default:
+		require.ErrorIs(t, err, kzg.ErrVerifyOpeningProof)
+	}
+}
+
+// TestSpecVectors runs the KZG test vectors of the consensus specifications with the
+// mainnet trusted setup. The cases with a null output must be rejected.
+func TestSpecVectors(t *testing.T) {
+	ctx := mainnetContext(t)
+
+	t.Run("blob_to_kzg_commitment", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Blob string `yaml:"blob"`
+			}
+			Output *string `yaml:"output"`
+		}
+		runSpecTests(t, "blob_to_kzg_commitment", func(t *testing.T, test *Test) {
+			var blob Blob
+			if !decodeFixed(t, blob[:], test.Input.Blob) {
+				require.Nil(t, test.Output)
+				return
+			}
+			commitment, err := ctx.BlobToKZGCommitment(&blob)
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var expected KZGCommitment
+			require.True(t, decodeFixed(t, expected[:], *test.Output))
+			require.Equal(t, expected, commitment)
+		})
+	})
+
+	t.Run("compute_kzg_proof", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Blob string `yaml:"blob"`
+				Z    string `yaml:"z"`
+			}
+			Output *[2]string `yaml:"output"`
+		}
+		runSpecTests(t, "compute_kzg_proof", func(t *testing.T, test *Test) {
+			var blob Blob
+			var z Bytes32
+			if !decodeFixed(t, blob[:], test.Input.Blob) || !decodeFixed(t, z[:], test.Input.Z) {
+				require.Nil(t, test.Output)
+				return
+			}
+			proof, y, err := ctx.ComputeKZGProof(&blob, z)
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var expectedProof KZGProof
+			var expectedY Bytes32
+			require.True(t, decodeFixed(t, expectedProof[:], test.Output[0]))
+			require.True(t, decodeFixed(t, expectedY[:], test.Output[1]))
+			require.Equal(t, expectedProof, proof)
+			require.Equal(t, expectedY, y)
+		})
+	})
+
+	t.Run("compute_blob_kzg_proof", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Blob       string `yaml:"blob"`
+				Commitment string `yaml:"commitment"`
+			}
+			Output *string `yaml:"output"`
+		}
+		runSpecTests(t, "compute_blob_kzg_proof", func(t *testing.T, test *Test) {
+			var blob Blob
+			var commitment KZGCommitment
+			if !decodeFixed(t, blob[:], test.Input.Blob) || !decodeFixed(t, commitment[:], test.Input.Commitment) {
+				require.Nil(t, test.Output)
+				return
+			}
+			proof, err := ctx.ComputeBlobKZGProof(&blob, commitment)
+			if test.Output == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var expected KZGProof
+			require.True(t, decodeFixed(t, expected[:], *test.Output))
+			require.Equal(t, expected, proof)
+		})
+	})
+
+	t.Run("verify_kzg_proof", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Commitment string `yaml:"commitment"`
+				Z          string `yaml:"z"`
+				Y          string `yaml:"y"`
+				Proof      string `yaml:"proof"`
+			}
+			Output *bool `yaml:"output"`
+		}
+		runSpecTests(t, "verify_kzg_proof", func(t *testing.T, test *Test) {
+			var commitment KZGCommitment
+			var z, y Bytes32
+			var proof KZGProof
+			if !decodeFixed(t, commitment[:], test.Input.Commitment) ||
+				!decodeFixed(t, z[:], test.Input.Z) ||
+				!decodeFixed(t, y[:], test.Input.Y) ||
+				!decodeFixed(t, proof[:], test.Input.Proof) {
+				require.Nil(t, test.Output)
+				return
+			}
+			checkVerification(t, ctx.VerifyKZGProof(commitment, z, y, proof), test.Output)
+		})
+	})
+
+	t.Run("verify_blob_kzg_proof", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Blob       string `yaml:"blob"`
+				Commitment string `yaml:"commitment"`
+				Proof      string `yaml:"proof"`
+			}
+			Output *bool `yaml:"output"`
+		}
+		runSpecTests(t, "verify_blob_kzg_proof", func(t *testing.T, test *Test) {
+			var blob Blob
+			var commitment KZGCommitment
+			var proof KZGProof
+			if !decodeFixed(t, blob[:], test.Input.Blob) ||
+				!decodeFixed(t, commitment[:], test.Input.Commitment) ||
+				!decodeFixed(t, proof[:], test.Input.Proof) {
+				require.Nil(t, test.Output)
+				return
+			}
+			checkVerification(t, ctx.VerifyBlobKZGProof(&blob, commitment, proof), test.Output)
+		})
+	})
+
+	t.Run("verify_blob_kzg_proof_batch", func(t *testing.T) {
+		type Test struct {
+			Input struct {
+				Blobs       []string `yaml:"blobs"`
+				Commitments []string `yaml:"commitments"`
+				Proofs      []string `yaml:"proofs"`
+			}
+			Output *bool `yaml:"output"`
+		}
+		runSpecTests(t, "verify_blob_kzg_proof_batch", func(t *testing.T, test *Test) {
+			blobs := make([]Blob, len(test.Input.Blobs))
+			commitments := make([]KZGCommitment, len(test.Input.Commitments))
+			proofs := make([]KZGProof, len(test.Input.Proofs))
+			for i := range blobs {
+				if !decodeFixed(t, blobs[i][:], test.Input.Blobs[i]) {
+					require.Nil(t, test.Output)
+					return
+				}
+			}
+			for i := range commitments {
+				if !decodeFixed(t, commitments[i][:], test.Input.Commitments[i]) {
+					require.Nil(t, test.Output)
+					return
+				}
+			}
+			for i := range proofs {
+				if !decodeFixed(t, proofs[i][:], test.Input.Proofs[i]) {
+					require.Nil(t, test.Output)
+					return
+				}
+			}
+			checkVerification(t, ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs), test.Output)
+		})
+	})
+}